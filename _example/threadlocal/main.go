@@ -48,6 +48,8 @@ func (app *App) run() {
 }
 
 func main() {
+	needle.InitGlobalRegistry(needle.WithLogger(slog.Default()))
+
 	app := App{
 		start:      1,
 		end:        200,
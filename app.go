@@ -0,0 +1,291 @@
+package needle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// Exit codes returned by App.Run.
+const (
+	ExitOK      = 0 // The application started and stopped without errors.
+	ExitFailure = 1 // The application failed to start or to stop cleanly.
+)
+
+const defaultStopTimeout = 30 * time.Second
+
+// Hook is a function run by an App when it starts or stops.
+type Hook func(ctx context.Context) error
+
+// Lifecycle pairs the hooks starting and stopping a component of an App. Either hook may be nil.
+type Lifecycle struct {
+	OnStart Hook
+	OnStop  Hook
+}
+
+// AppOptions holds configuration options for an App.
+type AppOptions struct {
+	registry     *Registry
+	stopTimeout  time.Duration
+	signals      []os.Signal
+	buildOptions []BuildOptionFunc
+	superviseOpt []SuperviseOptionFunc
+	errorHandler func(error)
+}
+
+// AppOptionFunc is a function that modifies an AppOptions struct.
+type AppOptionFunc func(*AppOptions)
+
+// WithRegistry sets the registry owned by an App. Defaults to a new registry.
+//
+// Example:
+//
+//	app := needle.NewApp(needle.WithRegistry(registry))
+func WithRegistry(registry *Registry) AppOptionFunc {
+	return func(o *AppOptions) {
+		o.registry = registry
+	}
+}
+
+// WithStopTimeout sets the deadline for running the stop hooks and shutting down the registry. Defaults to 30s.
+//
+// Example:
+//
+//	app := needle.NewApp(needle.WithStopTimeout(10 * time.Second))
+func WithStopTimeout(timeout time.Duration) AppOptionFunc {
+	return func(o *AppOptions) {
+		o.stopTimeout = timeout
+	}
+}
+
+// WithSignals sets the signals stopping an App. Defaults to SIGINT and SIGTERM, which are kept when no signal is
+// given.
+//
+// Example:
+//
+//	app := needle.NewApp(needle.WithSignals(os.Interrupt))
+func WithSignals(signals ...os.Signal) AppOptionFunc {
+	return func(o *AppOptions) {
+		if len(signals) > 0 {
+			o.signals = signals
+		}
+	}
+}
+
+// WithBuildOptions sets the options used to build the registry when an App starts.
+//
+// Example:
+//
+//	app := needle.NewApp(needle.WithBuildOptions(needle.WithEagerSingletons()))
+func WithBuildOptions(optFuncs ...BuildOptionFunc) AppOptionFunc {
+	return func(o *AppOptions) {
+		o.buildOptions = optFuncs
+	}
+}
+
+// WithSuperviseOptions sets the options used to supervise the runners of the registry while an App runs.
+//
+// Example:
+//
+//	app := needle.NewApp(needle.WithSuperviseOptions(needle.WithRestartPolicy(needle.RestartAlways)))
+func WithSuperviseOptions(optFuncs ...SuperviseOptionFunc) AppOptionFunc {
+	return func(o *AppOptions) {
+		o.superviseOpt = optFuncs
+	}
+}
+
+// WithErrorHandler sets the function receiving the errors of an App. Defaults to printing them to stderr.
+//
+// Example:
+//
+//	app := needle.NewApp(needle.WithErrorHandler(func(err error) { slog.Error("app", "error", err) }))
+func WithErrorHandler(handler func(error)) AppOptionFunc {
+	return func(o *AppOptions) {
+		o.errorHandler = handler
+	}
+}
+
+// newAppOptions creates a new AppOptions struct from the provided option functions.
+func newAppOptions(optFuncs ...AppOptionFunc) *AppOptions {
+	opt := &AppOptions{
+		registry:     nil,
+		stopTimeout:  defaultStopTimeout,
+		signals:      []os.Signal{os.Interrupt, syscall.SIGTERM},
+		buildOptions: nil,
+		superviseOpt: nil,
+		errorHandler: func(err error) { fmt.Fprintln(os.Stderr, err) },
+	}
+
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	if opt.registry == nil {
+		opt.registry = NewRegistry()
+	}
+
+	return opt
+}
+
+// App runs an application built around a registry.
+//
+// Running an App builds its registry, runs the start hooks, supervises the runners of the registry, and blocks
+// until a signal is received or the context is canceled. It then stops the runners, runs the stop hooks of the
+// started components in reverse order and shuts down the registry within the stop timeout.
+type App struct {
+	opt        *AppOptions
+	lifecycles []Lifecycle
+	started    int // number of lifecycles started, in the order they were appended.
+	supervisor *Supervisor
+	lock       sync.RWMutex
+}
+
+// NewApp creates and returns a new App.
+//
+// Example:
+//
+//	app := needle.NewApp(needle.WithStopTimeout(10 * time.Second))
+//	_ = needle.RegisterSingletonInstanceToRegistry(app.Registry(), &Config{})
+//	app.Append(needle.Lifecycle{OnStart: server.Start, OnStop: server.Stop})
+//	os.Exit(app.Run(context.Background()))
+func NewApp(optFuncs ...AppOptionFunc) *App {
+	return &App{ //nolint:exhaustruct
+		opt:        newAppOptions(optFuncs...),
+		lifecycles: nil,
+		started:    0,
+		supervisor: nil,
+	}
+}
+
+// Registry returns the registry owned by the App.
+func (a *App) Registry() *Registry {
+	return a.opt.registry
+}
+
+// Runners returns the status of the runners supervised by the App, or nil if the App is not running.
+func (a *App) Runners() []RunnerStatus {
+	a.lock.RLock()
+	defer a.lock.RUnlock()
+
+	if a.supervisor == nil {
+		return nil
+	}
+
+	return a.supervisor.Status()
+}
+
+// Append adds the lifecycle of a component to the App. Start hooks run in the order they are appended, and stop
+// hooks in the reverse order. The stop hook of a component only runs if its start hook succeeded.
+//
+// Example:
+//
+//	app.Append(needle.Lifecycle{OnStart: server.Start, OnStop: server.Stop})
+func (a *App) Append(lifecycle Lifecycle) {
+	a.lifecycles = append(a.lifecycles, lifecycle)
+}
+
+// OnStart appends a component with only a start hook to the App.
+func (a *App) OnStart(hook Hook) {
+	a.Append(Lifecycle{OnStart: hook, OnStop: nil})
+}
+
+// OnStop appends a component with only a stop hook to the App. It runs if the App started every component
+// appended before it.
+func (a *App) OnStop(hook Hook) {
+	a.Append(Lifecycle{OnStart: nil, OnStop: hook})
+}
+
+// Run starts the App, blocks until a signal is received or the context is canceled, stops the App, and
+// returns the exit code of the application.
+//
+// When building the registry or a start hook fails, the App stops immediately, skipping the stop hooks of the
+// components that did not start. Every other stop hook runs even if another fails, and all errors are passed to
+// the error handler.
+func (a *App) Run(ctx context.Context) int {
+	runCtx, stop := signal.NotifyContext(ctx, a.opt.signals...)
+	defer stop()
+
+	startErr := a.start(runCtx)
+	if startErr == nil {
+		<-runCtx.Done()
+	}
+
+	stopCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), a.opt.stopTimeout)
+	defer cancel()
+
+	stopErr := a.stop(stopCtx)
+
+	if err := errors.Join(startErr, stopErr); err != nil {
+		a.opt.errorHandler(err)
+
+		return ExitFailure
+	}
+
+	return ExitOK
+}
+
+// start builds the registry, runs the start hooks and supervises the runners, stopping at the first failure.
+func (a *App) start(ctx context.Context) error {
+	if _, err := a.opt.registry.Build(ctx, a.opt.buildOptions...); err != nil {
+		return fmt.Errorf("%w: %w", ErrAppStart, err)
+	}
+
+	for _, lifecycle := range a.lifecycles {
+		if lifecycle.OnStart != nil {
+			if err := lifecycle.OnStart(ctx); err != nil {
+				return fmt.Errorf("%w: %w", ErrAppStart, err)
+			}
+		}
+
+		a.started++
+	}
+
+	supervisor, err := a.opt.registry.Supervise(ctx, a.opt.superviseOpt...)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrAppStart, err)
+	}
+
+	a.lock.Lock()
+	a.supervisor = supervisor
+	a.lock.Unlock()
+
+	return nil
+}
+
+// stop stops the runners, runs the stop hooks of the started components in reverse order and shuts down the
+// registry.
+func (a *App) stop(ctx context.Context) error {
+	var errs []error
+
+	a.lock.Lock()
+	supervisor := a.supervisor
+	a.supervisor = nil
+	a.lock.Unlock()
+
+	if supervisor != nil {
+		if err := supervisor.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("%w: %w", ErrAppStop, err))
+		}
+	}
+
+	for idx := a.started - 1; idx >= 0; idx-- {
+		if hook := a.lifecycles[idx].OnStop; hook != nil {
+			if err := hook(ctx); err != nil {
+				errs = append(errs, fmt.Errorf("%w: %w", ErrAppStop, err))
+			}
+		}
+	}
+
+	a.started = 0
+
+	if _, err := a.opt.registry.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("%w: %w", ErrAppStop, err))
+	}
+
+	return errors.Join(errs...)
+}
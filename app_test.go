@@ -0,0 +1,221 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNeedleApp = errors.New("app hook failed")
+
+func TestNeedle_App_Run(t *testing.T) {
+	log := &testNeedleShutdownLog{} //nolint:exhaustruct
+	app := needle.NewApp()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(app.Registry(), &testNeedleShutdownDatabase{log: log}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	app.OnStart(func(context.Context) error {
+		log.add("start1")
+
+		return nil
+	})
+	app.OnStart(func(context.Context) error {
+		log.add("start2")
+		cancel()
+
+		return nil
+	})
+	app.OnStop(func(context.Context) error {
+		log.add("stop1")
+
+		return nil
+	})
+	app.OnStop(func(context.Context) error {
+		log.add("stop2")
+
+		return nil
+	})
+
+	assert.Equal(t, needle.ExitOK, app.Run(ctx))
+	assert.Equal(t, []string{"start1", "start2", "stop2", "stop1", "database"}, log.names)
+	assert.Empty(t, app.Registry().RegisteredServices())
+}
+
+func TestNeedle_App_Run_Signal(t *testing.T) {
+	app := needle.NewApp(needle.WithSignals(os.Interrupt))
+	stopped := false
+
+	app.OnStart(func(context.Context) error {
+		process, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			return err
+		}
+
+		return process.Signal(os.Interrupt)
+	})
+	app.OnStop(func(context.Context) error {
+		stopped = true
+
+		return nil
+	})
+
+	assert.Equal(t, needle.ExitOK, app.Run(context.Background()))
+	assert.True(t, stopped)
+}
+
+func TestNeedle_App_Run_NoSignals(t *testing.T) {
+	const wait = 50 * time.Millisecond
+
+	app := needle.NewApp(needle.WithSignals())
+
+	app.OnStart(func(context.Context) error {
+		process, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			return err
+		}
+
+		return process.Signal(syscall.SIGURG)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), wait)
+	defer cancel()
+
+	start := time.Now()
+
+	assert.Equal(t, needle.ExitOK, app.Run(ctx))
+	assert.GreaterOrEqual(t, time.Since(start), wait, "stopped by a signal other than the defaults")
+}
+
+func TestNeedle_App_Run_StartFailure(t *testing.T) {
+	var reported error
+
+	app := needle.NewApp(needle.WithErrorHandler(func(err error) { reported = err }))
+	started, stopped := false, false
+
+	app.OnStart(func(context.Context) error { return errTestNeedleApp })
+	app.OnStart(func(context.Context) error {
+		started = true
+
+		return nil
+	})
+	app.OnStop(func(context.Context) error {
+		stopped = true
+
+		return nil
+	})
+
+	assert.Equal(t, needle.ExitFailure, app.Run(context.Background()))
+	assert.False(t, started)
+	assert.False(t, stopped)
+	require.ErrorIs(t, reported, needle.ErrAppStart)
+	require.ErrorIs(t, reported, errTestNeedleApp)
+}
+
+func TestNeedle_App_Run_StopStartedOnly(t *testing.T) {
+	log := &testNeedleShutdownLog{} //nolint:exhaustruct
+	app := needle.NewApp(needle.WithErrorHandler(func(error) {}))
+
+	app.OnStop(func(context.Context) error {
+		log.add("cleanup")
+
+		return nil
+	})
+
+	for _, name := range []string{"db", "cache", "server", "worker"} {
+		app.Append(needle.Lifecycle{
+			OnStart: func(context.Context) error {
+				if name == "server" {
+					return errTestNeedleApp
+				}
+
+				log.add("start " + name)
+
+				return nil
+			},
+			OnStop: func(context.Context) error {
+				log.add("stop " + name)
+
+				return nil
+			},
+		})
+	}
+
+	app.Append(needle.Lifecycle{OnStart: nil, OnStop: func(context.Context) error {
+		log.add("flush")
+
+		return nil
+	}})
+
+	assert.Equal(t, needle.ExitFailure, app.Run(context.Background()))
+	assert.Equal(t, []string{"start db", "start cache", "stop cache", "stop db", "cleanup"}, log.names)
+}
+
+func TestNeedle_App_Run_StopFailure(t *testing.T) {
+	var reported error
+
+	app := needle.NewApp(needle.WithErrorHandler(func(err error) { reported = err }))
+	stopped := false
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	app.OnStop(func(context.Context) error {
+		stopped = true
+
+		return nil
+	})
+	app.OnStop(func(context.Context) error { return errTestNeedleApp })
+
+	assert.Equal(t, needle.ExitFailure, app.Run(ctx))
+	assert.True(t, stopped)
+	require.ErrorIs(t, reported, needle.ErrAppStop)
+	require.ErrorIs(t, reported, errTestNeedleApp)
+}
+
+func TestNeedle_App_Run_StopTimeout(t *testing.T) {
+	var reported error
+
+	app := needle.NewApp(
+		needle.WithStopTimeout(10*time.Millisecond),
+		needle.WithErrorHandler(func(err error) { reported = err }),
+	)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	app.OnStop(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	assert.Equal(t, needle.ExitFailure, app.Run(ctx))
+	require.ErrorIs(t, reported, context.DeadlineExceeded)
+}
+
+func TestNeedle_App_Run_BuildFailure(t *testing.T) {
+	var reported error
+
+	app := needle.NewApp(needle.WithErrorHandler(func(err error) { reported = err }))
+	started := false
+
+	require.NoError(t, needle.RegisterToRegistry[testNeedleShutdownRepo](app.Registry(), needle.Singleton))
+	app.OnStart(func(context.Context) error {
+		started = true
+
+		return nil
+	})
+
+	assert.Equal(t, needle.ExitFailure, app.Run(context.Background()))
+	assert.False(t, started)
+	require.ErrorIs(t, reported, needle.ErrAppStart)
+}
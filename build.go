@@ -0,0 +1,344 @@
+package needle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"runtime"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// BuildOptions holds configuration options for building a registry.
+type BuildOptions struct {
+	eagerSingletons bool
+	parallelism     int
+}
+
+// BuildOptionFunc is a function that modifies a BuildOptions struct.
+type BuildOptionFunc func(*BuildOptions)
+
+// WithEagerSingletons makes Build create every lazily created singleton up front.
+//
+// Example:
+//
+//	report, err := registry.Build(ctx, needle.WithEagerSingletons())
+func WithEagerSingletons() BuildOptionFunc {
+	return func(o *BuildOptions) {
+		o.eagerSingletons = true
+	}
+}
+
+// WithParallelism sets the maximum number of singletons created concurrently by Build.
+// Defaults to runtime.GOMAXPROCS(0); values below 1 create singletons sequentially.
+//
+// Example:
+//
+//	report, err := registry.Build(ctx, needle.WithEagerSingletons(), needle.WithParallelism(4))
+func WithParallelism(n int) BuildOptionFunc {
+	return func(o *BuildOptions) {
+		o.parallelism = max(n, 1)
+	}
+}
+
+// newBuildOptions creates a new BuildOptions struct from the provided option functions.
+func newBuildOptions(optFuncs ...BuildOptionFunc) *BuildOptions {
+	opt := &BuildOptions{eagerSingletons: false, parallelism: runtime.GOMAXPROCS(0)}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	return opt
+}
+
+// BuildReport describes the outcome of building a registry.
+type BuildReport struct {
+	Services []ServiceBuild // Singletons created by the build, sorted by name.
+	Duration time.Duration  // Total duration of the build.
+}
+
+// ServiceBuild describes the creation of a singleton during a build.
+type ServiceBuild struct {
+	Name     string        // Display name of the service.
+	Type     reflect.Type  // Type of the service.
+	Duration time.Duration // Time spent creating the service, excluding the time spent waiting for dependencies.
+	Err      error         // Error that occurred while creating the service, nil on success.
+}
+
+// Build validates and prepares the global registry.
+// See Registry.Build for details.
+func Build(ctx context.Context, optFuncs ...BuildOptionFunc) (BuildReport, error) {
+	ensureGlobalRegistryInitialized()
+
+	return globalRegistry.Build(ctx, optFuncs...)
+}
+
+// Build validates the dependency graph of the registry and optionally creates every singleton up front.
+// Returns a report of the created singletons and the joined errors of every failure.
+//
+// The graph is valid when every dependency of a service created by the registry is registered and no service
+// depends on itself. With WithStrictLifetimes, captive dependencies also make the graph invalid, see
+// Registry.Validate. With WithEagerSingletons, singletons are then created concurrently, up to the configured
+// parallelism, with each singleton created after the singletons it depends on.
+//
+// Example:
+//
+//	report, err := registry.Build(ctx, needle.WithEagerSingletons(), needle.WithParallelism(4))
+//	for _, service := range report.Services {
+//	    log.Printf("%s created in %s", service.Name, service.Duration)
+//	}
+//	if err != nil {
+//	    ...
+//	}
+func (r *Registry) Build(ctx context.Context, optFuncs ...BuildOptionFunc) (BuildReport, error) {
+	opt := newBuildOptions(optFuncs...)
+	start := time.Now()
+	report := BuildReport{Services: nil, Duration: 0}
+
+	errs := r.validateGraph()
+	if r.err != nil {
+		errs = append(errs, r.err)
+	}
+
+	if r.strict {
+		errs = append(errs, r.validateLifetimes()...)
+	}
+
+	if len(errs) > 0 {
+		report.Duration = time.Since(start)
+
+		return report, errors.Join(errs...)
+	}
+
+	if opt.eagerSingletons {
+		report.Services = r.buildSingletons(ctx, opt.parallelism)
+	}
+
+	report.Duration = time.Since(start)
+
+	errs = make([]error, 0, len(report.Services))
+	for _, service := range report.Services {
+		errs = append(errs, service.Err)
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// snapshotEntries returns a copy of the registered service entries.
+func (r *Registry) snapshotEntries() map[reflect.Type]serviceEntry {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	entries := make(map[reflect.Type]serviceEntry, len(r.registeredServices))
+	for typ, entry := range r.registeredServices {
+		entries[typ] = entry
+	}
+
+	return entries
+}
+
+// validateGraph checks that every dependency of a service created by the registry is registered and that no
+// service depends on itself, directly or transitively.
+func (r *Registry) validateGraph() []error {
+	entries := r.snapshotEntries()
+
+	var errs []error
+
+	for _, typ := range sortedTypes(entries) {
+		entry := entries[typ]
+
+		for _, dep := range entry.dependencies() {
+			if _, found := entries[dep]; !found {
+				opt := newResolutionOptions().withDependent(typ)
+				errs = append(errs, newResolutionError(r, dep, "", opt, ErrNotRegistered))
+			}
+		}
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+
+	state := make(map[reflect.Type]int, len(entries))
+
+	var visit func(typ reflect.Type, path []reflect.Type)
+
+	visit = func(typ reflect.Type, path []reflect.Type) {
+		state[typ] = visiting
+		entry := entries[typ]
+
+		for _, dep := range entry.dependencies() {
+			if _, found := entries[dep]; !found {
+				continue
+			}
+
+			switch state[dep] {
+			case visiting:
+				cycle := path[slices.Index(path, dep):]
+				opt := &ResolutionOptions{chain: slices.Clone(cycle)} //nolint:exhaustruct
+				errs = append(errs, newResolutionError(r, dep, entries[dep].lifetime, opt, ErrCircularDependency))
+			case unvisited:
+				visit(dep, append(path, dep))
+			}
+		}
+
+		state[typ] = visited
+	}
+
+	for _, typ := range sortedTypes(entries) {
+		if state[typ] == unvisited {
+			visit(typ, []reflect.Type{typ})
+		}
+	}
+
+	return errs
+}
+
+// buildSingletons creates the lazily created singletons of the registry concurrently, creating each singleton
+// after the singletons it depends on. The dependency graph must be valid.
+func (r *Registry) buildSingletons(ctx context.Context, parallelism int) []ServiceBuild {
+	entries := r.snapshotEntries()
+
+	r.lock.RLock()
+
+	var singletons []reflect.Type
+
+	for typ, entry := range entries {
+		if entry.lifetime == Singleton && !r.instances[Singleton][""][typ].value.IsValid() {
+			singletons = append(singletons, typ)
+		}
+	}
+
+	r.lock.RUnlock()
+
+	var (
+		waitGroup sync.WaitGroup
+		mutex     sync.Mutex
+		builds    = make([]ServiceBuild, len(singletons))
+		done      = make(map[reflect.Type]chan struct{}, len(singletons))
+		failed    = make(map[reflect.Type]bool, len(singletons))
+		semaphore = make(chan struct{}, parallelism)
+	)
+
+	for _, typ := range singletons {
+		done[typ] = make(chan struct{})
+	}
+
+	for idx, typ := range singletons {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+			defer close(done[typ])
+
+			build := ServiceBuild{Name: internal.ServiceName(typ), Type: typ, Duration: 0, Err: nil}
+
+			defer func() {
+				mutex.Lock()
+				builds[idx] = build
+				failed[typ] = build.Err != nil
+				mutex.Unlock()
+			}()
+
+			for _, dep := range singletonDependencies(entries, typ) {
+				depDone, pending := done[dep]
+				if !pending {
+					continue // already created before the build
+				}
+
+				<-depDone
+
+				mutex.Lock()
+				depFailed := failed[dep]
+				mutex.Unlock()
+
+				if depFailed {
+					build.Err = fmt.Errorf("%w %s: %s", ErrDependencyFailed, build.Name, internal.ServiceName(dep))
+
+					return
+				}
+			}
+
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				build.Err = fmt.Errorf("%s: %w", build.Name, ctx.Err())
+
+				return
+			}
+
+			defer func() { <-semaphore }()
+
+			start := time.Now()
+			_, build.Err = resolveType(r, typ, newResolutionOptions())
+			build.Duration = time.Since(start)
+		}()
+	}
+
+	waitGroup.Wait()
+
+	slices.SortFunc(builds, func(a, b ServiceBuild) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return builds
+}
+
+// singletonDependencies returns the lazily created singletons a service depends on, looking through
+// dependencies with other lifetimes, which are created along with the service.
+func singletonDependencies(entries map[reflect.Type]serviceEntry, typ reflect.Type) []reflect.Type {
+	entry := entries[typ]
+
+	var (
+		deps    []reflect.Type
+		visited = map[reflect.Type]bool{typ: true}
+		pending = entry.dependencies()
+	)
+
+	for len(pending) > 0 {
+		dep := pending[0]
+		pending = pending[1:]
+
+		if visited[dep] {
+			continue
+		}
+
+		visited[dep] = true
+
+		depEntry, found := entries[dep]
+		if !found || depEntry.instance {
+			continue
+		}
+
+		if depEntry.lifetime == Singleton {
+			deps = append(deps, dep)
+
+			continue
+		}
+
+		pending = append(pending, depEntry.dependencies()...)
+	}
+
+	return deps
+}
+
+// sortedTypes returns the types of the entries sorted by name.
+func sortedTypes(entries map[reflect.Type]serviceEntry) []reflect.Type {
+	types := make([]reflect.Type, 0, len(entries))
+	for typ := range entries {
+		types = append(types, typ)
+	}
+
+	slices.SortFunc(types, func(a, b reflect.Type) int {
+		return strings.Compare(entries[a].name, entries[b].name)
+	})
+
+	return types
+}
@@ -0,0 +1,192 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedle_BuildEagerSingletons(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Config struct{}
+
+	type Cache struct{}
+
+	type Service struct {
+		Config *Config `needle:"inject"`
+		Cache  *Cache  `needle:"inject"`
+	}
+
+	var (
+		mutex sync.Mutex
+		order []string
+	)
+
+	record := func(name string) {
+		mutex.Lock()
+		defer mutex.Unlock()
+
+		order = append(order, name)
+	}
+
+	require.NoError(t, needle.RegisterFactory[Config](needle.Singleton, func() *Config {
+		record("config")
+
+		return &Config{}
+	}))
+	require.NoError(t, needle.RegisterFactory[Cache](needle.Singleton, func() *Cache {
+		record("cache")
+
+		return &Cache{}
+	}))
+	require.NoError(t, needle.RegisterFactory[Service](needle.Singleton, func(cfg *Config, cache *Cache) *Service {
+		record("service")
+
+		return &Service{Config: cfg, Cache: cache}
+	}))
+
+	report, err := needle.Build(context.Background(), needle.WithEagerSingletons())
+	require.NoError(t, err)
+	require.Len(t, report.Services, 3)
+	assert.Equal(t, "github.com/goplexhq/needle_test.Cache", report.Services[0].Name)
+	require.Len(t, order, 3)
+	assert.Equal(t, "service", order[2])
+
+	for _, service := range report.Services {
+		require.NoError(t, service.Err)
+	}
+
+	_, err = needle.Resolve[Service]()
+	require.NoError(t, err)
+	assert.Len(t, order, 3) // no singleton created again
+}
+
+func TestNeedle_BuildParallelism(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type ServiceA struct{}
+
+	type ServiceB struct{}
+
+	registry := needle.NewRegistry()
+	started := make(chan struct{}, 2)
+	release := make(chan struct{})
+
+	var running, peak atomic.Int32
+
+	factory := func() {
+		peak.Store(max(peak.Load(), running.Add(1)))
+		started <- struct{}{}
+		<-release
+		running.Add(-1)
+	}
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[ServiceA](registry, needle.Singleton, func() *ServiceA {
+		factory()
+
+		return &ServiceA{}
+	}))
+	require.NoError(t, needle.RegisterFactoryToRegistry[ServiceB](registry, needle.Singleton, func() *ServiceB {
+		factory()
+
+		return &ServiceB{}
+	}))
+
+	go func() {
+		<-started
+		<-started
+		close(release)
+	}()
+
+	report, err := registry.Build(context.Background(), needle.WithEagerSingletons(), needle.WithParallelism(2))
+	require.NoError(t, err)
+	assert.Len(t, report.Services, 2)
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestNeedle_BuildErrors(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Failing struct{}
+
+	type Dependent struct {
+		Failing *Failing `needle:"inject"`
+	}
+
+	type Healthy struct{}
+
+	registry := needle.NewRegistry()
+	errFactory := errors.New("factory failed") //nolint:err113
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[Failing](registry, needle.Singleton,
+		func() (*Failing, error) {
+			time.Sleep(time.Millisecond)
+
+			return nil, errFactory
+		}))
+	require.NoError(t, needle.RegisterToRegistry[Dependent](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterToRegistry[Healthy](registry, needle.Singleton))
+
+	report, err := registry.Build(context.Background(), needle.WithEagerSingletons(), needle.WithParallelism(1))
+	require.ErrorIs(t, err, errFactory)
+	require.ErrorIs(t, err, needle.ErrDependencyFailed)
+	require.Len(t, report.Services, 3)
+	require.ErrorIs(t, report.Services[0].Err, needle.ErrDependencyFailed)
+	require.ErrorIs(t, report.Services[1].Err, errFactory)
+	require.NoError(t, report.Services[2].Err)
+}
+
+func TestNeedle_BuildWithCreatedDependency(t *testing.T) {
+	type Config struct{}
+
+	type Service struct {
+		Config *Config `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[Config](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.Singleton))
+
+	config, err := needle.ResolveFromRegistry[Config](registry)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	report, err := registry.Build(ctx, needle.WithEagerSingletons())
+	require.NoError(t, err)
+	require.Len(t, report.Services, 1)
+
+	service, err := needle.ResolveFromRegistry[Service](registry)
+	require.NoError(t, err)
+	assert.Same(t, config, service.Config)
+}
+
+func TestNeedle_BuildValidatesGraph(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Missing struct{}
+
+	type Service struct {
+		Missing *Missing `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleCircularA](registry, needle.Transient))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleCircularB](registry, needle.Transient))
+
+	report, err := registry.Build(context.Background(), needle.WithEagerSingletons())
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+	require.ErrorIs(t, err, needle.ErrCircularDependency)
+	assert.Empty(t, report.Services)
+}
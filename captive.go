@@ -0,0 +1,158 @@
+package needle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Validate checks the services registered in the global registry. See Registry.Validate for details.
+func Validate() error {
+	ensureGlobalRegistryInitialized()
+
+	return globalRegistry.Validate()
+}
+
+// Validate checks that every dependency of a service created by the registry is registered, that no service
+// depends on itself, directly or transitively, and that no service captures a shorter-lived dependency.
+// Returns the joined ResolutionErrors of every problem found.
+//
+// A dependency is captive when a Singleton depends on a Scoped or ThreadLocal service, or when a Scoped and a
+// ThreadLocal service depend on each other: the dependent keeps the instance that existed when it was created.
+// Pooled services keep their dependencies across the resolutions borrowing them, so they capture Scoped and
+// ThreadLocal dependencies as Singletons do. Services wrapped in a Lazy keep the scope and thread ID of their
+// dependent, so they are captive as direct dependencies are. Transient services take the lifetime of their
+// dependents, prototypes are copied from their template, and services wrapped in a Provider are resolved on
+// demand with the options of each call, so none of them is captive.
+//
+// Example:
+//
+//	if err := registry.Validate(); err != nil {
+//	    log.Fatal(err)
+//	}
+func (r *Registry) Validate() error {
+	errs := append([]error{r.err}, r.validateGraph()...)
+	errs = append(errs, r.validateLifetimes()...)
+
+	return errors.Join(errs...)
+}
+
+// validateLifetimes reports the captive dependencies of the services created by the registry.
+func (r *Registry) validateLifetimes() []error {
+	entries := r.snapshotEntries()
+
+	var errs []error
+
+	for _, typ := range sortedTypes(entries) {
+		entry := entries[typ]
+		if entry.lifetime == Transient {
+			continue
+		}
+
+		visited := map[reflect.Type]bool{typ: true}
+
+		var visit func(path []reflect.Type)
+
+		visit = func(path []reflect.Type) {
+			last := entries[path[len(path)-1]]
+
+			for _, dep := range append(last.dependencies(), last.captured()...) {
+				depEntry, found := entries[dep]
+				if !found || visited[dep] {
+					continue
+				}
+
+				visited[dep] = true
+
+				if depEntry.lifetime == Transient {
+					visit(append(slices.Clip(path), dep))
+
+					continue
+				}
+
+				if isCaptive(entry.lifetime, depEntry.lifetime) {
+					errs = append(errs, newCaptiveError(dep, depEntry.lifetime, entry.lifetime, path, "", ""))
+				}
+			}
+		}
+
+		visit([]reflect.Type{typ})
+	}
+
+	return errs
+}
+
+// checkCaptive returns an error if the service being resolved is captured by the closest dependent in the chain
+// that is not Transient.
+func (r *Registry) checkCaptive(typ reflect.Type, lifetime Lifetime, opt *ResolutionOptions) error {
+	if lifetime == Transient || lifetime == Singleton || lifetime == Pooled || lifetime == Prototype {
+		return nil
+	}
+
+	for idx := len(opt.chain) - 1; idx >= 0; idx-- {
+		dependent, found := r.has(opt.chain[idx])
+		if !found || dependent.lifetime == Transient {
+			continue
+		}
+
+		if isCaptive(dependent.lifetime, lifetime) {
+			return newCaptiveError(typ, lifetime, dependent.lifetime, opt.chain, opt.scope, opt.threadID)
+		}
+
+		return nil
+	}
+
+	return nil
+}
+
+// checkCaptured returns an error in strict mode if a wrapper injected into the dependent being resolved captures
+// a service that would be captive as a direct dependency.
+func (r *Registry) checkCaptured(typ reflect.Type, opt *ResolutionOptions) error {
+	captured := capturedBy(typ)
+	if !r.strict || captured == nil {
+		return nil
+	}
+
+	entry, found := r.has(captured)
+	if !found {
+		return nil
+	}
+
+	return r.checkCaptive(captured, entry.lifetime, opt)
+}
+
+// isCaptive reports whether a service with the dependent lifetime captures a dependency with the given lifetime.
+func isCaptive(dependent, dependency Lifetime) bool {
+	switch dependency {
+	case Transient, Singleton, Pooled, Prototype:
+		return false
+	}
+
+	if dependent == Transient {
+		return false
+	}
+
+	return dependent != dependency
+}
+
+// newCaptiveError creates a ResolutionError describing a dependency captured by a longer-lived dependent.
+func newCaptiveError(
+	typ reflect.Type,
+	lifetime, dependentLifetime Lifetime,
+	chain []reflect.Type,
+	scope, threadID string,
+) *ResolutionError {
+	return &ResolutionError{
+		Name:        internal.ServiceName(typ),
+		Type:        typ,
+		Chain:       slices.Clone(chain),
+		Lifetime:    lifetime,
+		Scope:       scope,
+		ThreadID:    threadID,
+		Suggestions: nil,
+		Err:         fmt.Errorf("%w: %s service depends on %s service", ErrCaptiveDependency, dependentLifetime, lifetime),
+	}
+}
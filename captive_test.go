@@ -0,0 +1,133 @@
+package needle_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedleCaptiveSession struct{}
+
+type testNeedleCaptiveRepo struct {
+	Session *testNeedleCaptiveSession `needle:"inject"`
+}
+
+type testNeedleCaptiveService struct {
+	Repo *testNeedleCaptiveRepo `needle:"inject"`
+}
+
+// newCaptiveRegistry registers a singleton service depending on a scoped session through a transient repository.
+func newCaptiveRegistry(t *testing.T, optFuncs ...needle.RegistryOptionFunc) *needle.Registry {
+	t.Helper()
+
+	registry := needle.NewRegistry(optFuncs...)
+	require.NoError(t, needle.RegisterToRegistry[testNeedleCaptiveService](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleCaptiveRepo](registry, needle.Transient))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleCaptiveSession](registry, needle.Scoped,
+		needle.WithScope("request1")))
+
+	return registry
+}
+
+func TestNeedle_ValidateCaptiveDependency(t *testing.T) {
+	registry := newCaptiveRegistry(t)
+
+	err := registry.Validate()
+	require.ErrorIs(t, err, needle.ErrCaptiveDependency)
+
+	var resErr *needle.ResolutionError
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, reflect.TypeFor[testNeedleCaptiveSession](), resErr.Type)
+	assert.Equal(t, needle.Scoped, resErr.Lifetime)
+	assert.Equal(t, []reflect.Type{
+		reflect.TypeFor[testNeedleCaptiveService](),
+		reflect.TypeFor[testNeedleCaptiveRepo](),
+	}, resErr.Chain)
+	assert.Contains(t, err.Error(), "SINGLETON service depends on SCOPED service")
+
+	// without strict lifetimes, captive dependencies are only reported
+	_, err = needle.ResolveFromRegistry[testNeedleCaptiveService](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+}
+
+func TestNeedle_ValidateLifetimes(t *testing.T) {
+	type ThreadState struct{}
+
+	type Config struct{}
+
+	type Scoped struct {
+		State  *ThreadState `needle:"inject"`
+		Config *Config      `needle:"inject"`
+	}
+
+	type Transient struct {
+		Scoped *Scoped `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[Config](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterToRegistry[Transient](registry, needle.Transient))
+	require.NoError(t, needle.RegisterToRegistry[Scoped](registry, needle.Scoped, needle.WithScope("request1")))
+	require.NoError(t, needle.RegisterToRegistry[ThreadState](registry, needle.ThreadLocal, needle.WithThreadID("t1")))
+
+	err := registry.Validate()
+	require.ErrorIs(t, err, needle.ErrCaptiveDependency)
+	assert.Contains(t, err.Error(), "SCOPED service depends on THREAD_LOCAL service")
+	assert.NotContains(t, err.Error(), "depends on SINGLETON") // singletons are never captive
+}
+
+func TestNeedle_StrictLifetimes(t *testing.T) {
+	registry := newCaptiveRegistry(t, needle.WithStrictLifetimes())
+
+	_, err := needle.ResolveFromRegistry[testNeedleCaptiveService](registry, needle.WithScope("request1"))
+	require.ErrorIs(t, err, needle.ErrCaptiveDependency)
+
+	// the dependency is not captive when resolved on its own
+	_, err = needle.ResolveFromRegistry[testNeedleCaptiveRepo](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+
+	_, err = registry.Build(context.Background())
+	require.ErrorIs(t, err, needle.ErrCaptiveDependency)
+}
+
+func TestNeedle_LazyCaptiveDependency(t *testing.T) {
+	type Session struct{}
+
+	type Handler struct {
+		Session *needle.Lazy[Session] `needle:"inject"`
+	}
+
+	type Factory struct{}
+
+	type Sessions struct {
+		Sessions *needle.Provider[Session] `needle:"inject"`
+	}
+
+	newRegistry := func(optFuncs ...needle.RegistryOptionFunc) *needle.Registry {
+		registry := needle.NewRegistry(optFuncs...)
+		require.NoError(t, needle.RegisterToRegistry[Handler](registry, needle.Singleton))
+		require.NoError(t, needle.RegisterToRegistry[Sessions](registry, needle.Singleton))
+		require.NoError(t, needle.RegisterFactoryToRegistry[Factory](registry, needle.Singleton,
+			func(*needle.Lazy[Session]) *Factory { return &Factory{} }))
+		require.NoError(t, needle.RegisterToRegistry[Session](registry, needle.Scoped, needle.WithScope("request1")))
+
+		return registry
+	}
+
+	err := newRegistry().Validate()
+	require.ErrorIs(t, err, needle.ErrCaptiveDependency)
+	assert.Len(t, strings.Split(err.Error(), "\n"), 2) // the Handler and the Factory, not Sessions
+
+	registry := newRegistry(needle.WithStrictLifetimes())
+
+	_, err = needle.ResolveFromRegistry[Handler](registry, needle.WithScope("request1"))
+	require.ErrorIs(t, err, needle.ErrCaptiveDependency)
+
+	_, err = needle.ResolveFromRegistry[Sessions](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+}
@@ -0,0 +1,247 @@
+package needle
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Registration describes how a service was registered.
+type Registration string
+
+const (
+	TypeRegistration     Registration = "TYPE"     // The service was registered by type and is created by the registry.
+	InstanceRegistration Registration = "INSTANCE" // The service was registered with a pre-initialized instance.
+)
+
+// ServiceInfo describes a service registered in a registry.
+type ServiceInfo struct {
+	Name         string         // Display name of the service, in the form "<pkg>.<service>".
+	Type         reflect.Type   // Type of the service.
+	Lifetime     Lifetime       // Lifetime of the service.
+	Registration Registration   // How the service was last registered.
+	Scopes       []string       // Scopes the service is registered in, sorted.
+	ThreadIDs    []string       // Thread IDs the service is registered for, sorted.
+	Instances    []InstanceInfo // Instances created and currently held by the registry.
+	Dependencies []string       // Names of the services resolved to create an instance, sorted.
+}
+
+// InstanceInfo describes an instance of a service held by a registry.
+type InstanceInfo struct {
+	Key       string    // Key holding the instance, such as its scope, thread ID or the key of a custom lifetime.
+	Scope     string    // Scope holding the instance, empty unless the service is Scoped.
+	ThreadID  string    // Thread ID holding the instance, empty unless the service is ThreadLocal.
+	CreatedAt time.Time // Time at which the instance was created or registered.
+}
+
+// DescribeFilter reports whether a service should be included in the output of Describe.
+type DescribeFilter func(info ServiceInfo) bool
+
+// ByLifetime returns a filter including services with any of the given lifetimes.
+//
+// Example:
+//
+//	services := registry.Describe(needle.ByLifetime(needle.Scoped, needle.ThreadLocal))
+func ByLifetime(lifetimes ...Lifetime) DescribeFilter {
+	return func(info ServiceInfo) bool {
+		return slices.Contains(lifetimes, info.Lifetime)
+	}
+}
+
+// ByPackagePrefix returns a filter including services declared in packages whose import path starts with prefix.
+// Pointer, slice and array services are matched by the package of their element type.
+//
+// Example:
+//
+//	services := registry.Describe(needle.ByPackagePrefix("github.com/acme/app"))
+func ByPackagePrefix(prefix string) DescribeFilter {
+	return func(info ServiceInfo) bool {
+		return strings.HasPrefix(packagePath(info.Type), prefix)
+	}
+}
+
+// Describe returns information about the services registered in the global registry, sorted by name.
+// Only services accepted by every filter are included.
+func Describe(filters ...DescribeFilter) []ServiceInfo {
+	ensureGlobalRegistryInitialized()
+
+	return globalRegistry.Describe(filters...)
+}
+
+// Describe returns information about the registered services, sorted by name.
+// Only services accepted by every filter are included.
+//
+// Example:
+//
+//	for _, info := range registry.Describe(needle.ByLifetime(needle.Singleton)) {
+//	    fmt.Println(info.Name, info.Registration, info.Dependencies)
+//	}
+func (r *Registry) Describe(filters ...DescribeFilter) []ServiceInfo {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	infos := make([]ServiceInfo, 0, len(r.registeredServices))
+
+	for typ, entry := range r.registeredServices {
+		info := r.describe(typ, entry)
+
+		if !slices.ContainsFunc(filters, func(filter DescribeFilter) bool { return !filter(info) }) {
+			infos = append(infos, info)
+		}
+	}
+
+	slices.SortFunc(infos, func(a, b ServiceInfo) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return infos
+}
+
+// describe builds the ServiceInfo of a registered service. The caller must hold the registry lock.
+func (r *Registry) describe(typ reflect.Type, entry serviceEntry) ServiceInfo {
+	info := ServiceInfo{
+		Name:         entry.name,
+		Type:         typ,
+		Lifetime:     entry.lifetime,
+		Registration: TypeRegistration,
+		Scopes:       nil,
+		ThreadIDs:    nil,
+		Instances:    nil,
+		Dependencies: nil,
+	}
+
+	if entry.instance {
+		info.Registration = InstanceRegistration
+	}
+
+	appendInstance := func(inst serviceInstance, held heldInstance) {
+		if inst.value.IsValid() {
+			info.Instances = append(info.Instances, InstanceInfo{
+				Key:       held.key,
+				Scope:     held.scope,
+				ThreadID:  held.threadID,
+				CreatedAt: inst.createdAt,
+			})
+		}
+	}
+
+	for key, services := range r.instances[entry.lifetime] {
+		inst, found := services[typ]
+		if !found {
+			continue
+		}
+
+		held := newHeldInstance(typ, entry.lifetime, key, inst.value)
+
+		switch entry.lifetime {
+		case Scoped:
+			info.Scopes = append(info.Scopes, key)
+		case ThreadLocal:
+			info.ThreadIDs = append(info.ThreadIDs, key)
+		}
+
+		appendInstance(inst, held)
+	}
+
+	slices.Sort(info.Scopes)
+	slices.Sort(info.ThreadIDs)
+	slices.SortFunc(info.Instances, func(a, b InstanceInfo) int {
+		return strings.Compare(a.Key, b.Key)
+	})
+
+	for _, dep := range entry.dependencies() {
+		info.Dependencies = append(info.Dependencies, internal.ServiceName(dep))
+	}
+
+	slices.Sort(info.Dependencies)
+
+	return info
+}
+
+// dependenciesOf returns the distinct types injected into the fields of a struct type, including fields of
+// embedded structs and of fields annotated with the recurse option. Services wrapped in a Provider or Lazy are
+// resolved after injection and are not dependencies.
+func dependenciesOf(typ reflect.Type) []reflect.Type {
+	var deps []reflect.Type
+
+	if internal.IsStructType(typ) {
+		collectDependencies(typ, map[reflect.Type]bool{}, &deps, resolvedBy)
+	}
+
+	return deps
+}
+
+// capturedOf returns the distinct services wrapped in a Lazy injected into the fields of a struct type, as
+// dependenciesOf does.
+func capturedOf(typ reflect.Type) []reflect.Type {
+	var deps []reflect.Type
+
+	if internal.IsStructType(typ) {
+		collectDependencies(typ, map[reflect.Type]bool{}, &deps, capturedBy)
+	}
+
+	return deps
+}
+
+// collectDependencies appends the services picked from the types injected into the fields of a struct type to
+// deps. The pick function returns the service injected for a pointer to a type, or nil if none is picked.
+func collectDependencies(
+	structType reflect.Type,
+	seen map[reflect.Type]bool,
+	deps *[]reflect.Type,
+	pick func(reflect.Type) reflect.Type,
+) {
+	if seen[structType] {
+		return
+	}
+
+	seen[structType] = true
+
+	for idx := range structType.NumField() {
+		field := structType.Field(idx)
+
+		inject, recurse := parseInjectTag(field.Tag)
+		if inject && !recurse {
+			if field.Type.Kind() != reflect.Ptr {
+				continue
+			}
+
+			if dep := pick(field.Type.Elem()); dep != nil && !slices.Contains(*deps, dep) {
+				*deps = append(*deps, dep)
+			}
+
+			continue
+		}
+
+		if !recurse && !field.Anonymous {
+			continue
+		}
+
+		nested := field.Type
+		if nested.Kind() == reflect.Ptr {
+			nested = nested.Elem()
+		}
+
+		if internal.IsStructType(nested) {
+			collectDependencies(nested, seen, deps, pick)
+		}
+	}
+}
+
+// packagePath returns the import path of the package declaring a type, looking through pointer, slice and
+// array types to their element type.
+func packagePath(typ reflect.Type) string {
+	for typ != nil && typ.Name() == "" &&
+		(typ.Kind() == reflect.Ptr || typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array) {
+		typ = typ.Elem()
+	}
+
+	if typ == nil {
+		return ""
+	}
+
+	return typ.PkgPath()
+}
@@ -0,0 +1,86 @@
+package needle_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedle_Describe(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Logger struct{}
+
+	type Repo struct{}
+
+	type Base struct {
+		Logger *Logger `needle:"inject"`
+	}
+
+	type Service struct {
+		Base
+
+		Repo *Repo `needle:"inject"`
+	}
+
+	require.NoError(t, needle.RegisterSingletonInstance(&Logger{}))
+	require.NoError(t, needle.Register[Repo](needle.Scoped, needle.WithScope("b")))
+	require.NoError(t, needle.Register[Repo](needle.Scoped, needle.WithScope("a")))
+	require.NoError(t, needle.Register[Service](needle.Transient))
+
+	_, err := needle.Resolve[Repo](needle.WithScope("a"))
+	require.NoError(t, err)
+
+	infos := needle.Describe()
+	require.Len(t, infos, 3)
+
+	pkg := "github.com/goplexhq/needle_test."
+
+	logger := infos[0]
+	assert.Equal(t, pkg+"Logger", logger.Name)
+	assert.Equal(t, reflect.TypeFor[Logger](), logger.Type)
+	assert.Equal(t, needle.Singleton, logger.Lifetime)
+	assert.Equal(t, needle.InstanceRegistration, logger.Registration)
+	require.Len(t, logger.Instances, 1)
+	assert.False(t, logger.Instances[0].CreatedAt.IsZero())
+
+	repo := infos[1]
+	assert.Equal(t, pkg+"Repo", repo.Name)
+	assert.Equal(t, needle.TypeRegistration, repo.Registration)
+	assert.Equal(t, []string{"a", "b"}, repo.Scopes)
+	require.Len(t, repo.Instances, 1) // instances are created on first resolution
+	assert.Equal(t, "a", repo.Instances[0].Scope)
+	assert.Empty(t, repo.ThreadIDs)
+
+	service := infos[2]
+	assert.Equal(t, pkg+"Service", service.Name)
+	assert.Equal(t, needle.Transient, service.Lifetime)
+	assert.Equal(t, []string{pkg + "Logger", pkg + "Repo"}, service.Dependencies)
+}
+
+func TestNeedle_DescribeFilters(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{}
+
+	registry := needle.NewRegistry()
+
+	names := []string{}
+
+	require.NoError(t, needle.RegisterToRegistry[testStruct](registry, needle.ThreadLocal, needle.WithThreadID("1")))
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &names))
+
+	infos := registry.Describe(needle.ByLifetime(needle.ThreadLocal))
+	require.Len(t, infos, 1)
+	assert.Equal(t, []string{"1"}, infos[0].ThreadIDs)
+
+	infos = registry.Describe(needle.ByPackagePrefix("github.com/goplexhq"))
+	require.Len(t, infos, 1)
+	assert.Equal(t, reflect.TypeFor[testStruct](), infos[0].Type)
+
+	infos = registry.Describe(needle.ByPackagePrefix("github.com/goplexhq"), needle.ByLifetime(needle.Singleton))
+	assert.Empty(t, infos)
+}
@@ -0,0 +1,131 @@
+package needle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Shutdowner is implemented by services that release their resources with a deadline.
+// It is preferred over io.Closer when a service implements both.
+type Shutdowner interface {
+	Shutdown(ctx context.Context) error
+}
+
+// dispose disposes the instances that implement PreDestroyer, Shutdowner or io.Closer, notifies the observers,
+// and returns the joined errors.
+func (r *Registry) dispose(instances ...heldInstance) error {
+	var errs []error
+
+	for _, inst := range instances {
+		start := time.Now()
+
+		disposed, err := r.disposeHeld(context.Background(), inst)
+		if disposed {
+			r.observeDispose(inst, time.Since(start), err)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// disposeHeld disposes an instance held by the registry with the strategy of its lifetime.
+func (r *Registry) disposeHeld(ctx context.Context, inst heldInstance) (bool, error) {
+	if !inst.value.IsValid() || !inst.value.CanInterface() {
+		return false, nil
+	}
+
+	strategy, found := r.strategies[inst.lifetime]
+	if !found {
+		return disposeInstance(ctx, inst.value)
+	}
+
+	return strategy.Dispose(ctx, inst.typ, inst.value.Interface())
+}
+
+// DisposeInstance disposes an instance implementing PreDestroyer, Shutdowner or io.Closer, calling PreDestroy
+// first, and waits at most until the context is done. Returns false when the instance is not disposable.
+// This is how the built-in lifetimes dispose instances, and custom lifetime strategies may call it from Dispose.
+func DisposeInstance(ctx context.Context, instance any) (bool, error) {
+	return disposeInstance(ctx, reflect.ValueOf(instance))
+}
+
+// disposeInstance disposes an instance implementing PreDestroyer, Shutdowner or io.Closer, calling PreDestroy
+// first, and waits at most until the context is done. Returns false when the instance is not disposable.
+func disposeInstance(ctx context.Context, value reflect.Value) (bool, error) {
+	if !value.IsValid() || !value.CanInterface() {
+		return false, nil
+	}
+
+	inst := value.Interface()
+	preDestroyer, preDestroy := inst.(PreDestroyer)
+
+	var closeFunc func() error
+
+	switch closer := inst.(type) {
+	case Shutdowner:
+		closeFunc = func() error { return closer.Shutdown(ctx) }
+	case io.Closer:
+		closeFunc = closer.Close
+	default:
+		if !preDestroy {
+			return false, nil
+		}
+	}
+
+	disposeFunc := func() error {
+		if preDestroy {
+			preDestroyer.PreDestroy()
+		}
+
+		if closeFunc == nil {
+			return nil
+		}
+
+		return closeFunc()
+	}
+
+	name := internal.ServiceName(value.Type())
+
+	if err := ctx.Err(); err != nil {
+		return true, fmt.Errorf("%w: %s: %w", ErrDispose, name, err)
+	}
+
+	if err := callWithin(ctx, disposeFunc); err != nil {
+		return true, fmt.Errorf("%w: %s: %w", ErrDispose, name, err)
+	}
+
+	return true, nil
+}
+
+// callWithin calls a function in its own goroutine and waits at most until the context is done. A panic is
+// returned as an error wrapping ErrPanic.
+func callWithin(ctx context.Context, call func() error) error {
+	errChan := make(chan error, 1)
+
+	go func() {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				errChan <- fmt.Errorf("%w: %v", ErrPanic, recovered)
+			}
+		}()
+
+		errChan <- call()
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -1,19 +1,64 @@
 package needle
 
-import "reflect"
+import (
+	"reflect"
+	"time"
+)
 
 // serviceEntry holds metadata about a registered service.
 type serviceEntry struct {
 	name     string
+	typ      reflect.Type
 	lifetime Lifetime
+	instance bool          // whether the service was last registered with a pre-initialized instance.
+	factory  reflect.Value // function creating instances, invalid when instances are allocated and injected.
+	ttl      time.Duration // time to live of the instance of a singleton, zero if it never expires.
+	refresh  time.Duration // interval at which the instance of a singleton is refreshed, zero if never refreshed.
+	template reflect.Value // instance copied on every resolution of a prototype, invalid for other lifetimes.
+	deepCopy bool          // whether the template of a prototype is deeply copied.
 	value    *reflect.Value
 }
 
+// serviceInstance holds an instance of a registered service and the time it was created.
+// The value is invalid until the instance of a lazily created service is created.
+type serviceInstance struct {
+	value     reflect.Value
+	createdAt time.Time
+}
+
+// dependencies returns the types resolved when creating an instance of the service: the parameters of its
+// factory, or the types injected into its fields. Services registered with an instance have no dependencies.
+func (e *serviceEntry) dependencies() []reflect.Type {
+	if e.instance {
+		return nil
+	}
+
+	if e.factory.IsValid() {
+		return factoryDependencies(e.factory.Type(), resolvedBy)
+	}
+
+	return dependenciesOf(e.typ)
+}
+
+// captured returns the services wrapped in a Lazy when creating an instance of the service, which keep the scope
+// and thread ID of its creation and are therefore captured as dependencies are.
+func (e *serviceEntry) captured() []reflect.Type {
+	if e.instance {
+		return nil
+	}
+
+	if e.factory.IsValid() {
+		return factoryDependencies(e.factory.Type(), capturedBy)
+	}
+
+	return capturedOf(e.typ)
+}
+
 // withValue sets the value of a serviceEntry and returns the updated entry.
 //
 // Example:
 //
-//	entry := serviceEntry{name: "MyService", lifetime: needle.Singleton}
+//	entry := serviceEntry{name: "MyService", typ: reflect.TypeFor[MyService](), lifetime: needle.Singleton}
 //	entry = entry.withValue(reflect.ValueOf(&MyService{}))
 func (e *serviceEntry) withValue(value *reflect.Value) serviceEntry {
 	e.value = value
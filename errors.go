@@ -5,11 +5,40 @@ import "errors"
 var (
 	ErrRegistered          = errors.New("service already registered in the registry")
 	ErrNotRegistered       = errors.New("service not registered in the registry")
-	ErrInvalidServiceType  = errors.New("invalid service type: service must be a struct type")
+	ErrAmbiguousName       = errors.New("several registered services have the same name")
+	ErrInvalidServiceType  = errors.New("invalid service type: expected a non-nil, non-interface type")
+	ErrBuiltinLifetime     = errors.New("built-in lifetimes cannot be replaced")
+	ErrNilStrategy         = errors.New("lifetime strategy is nil")
+	ErrInvalidLifetime     = errors.New("invalid lifetime: expected a built-in lifetime or one added with WithLifetime")
 	ErrInvalidDestType     = errors.New("invalid destination type: expected a struct type")
 	ErrServiceTypeMismatch = errors.New("resolved service type does not match the expected type")
 	ErrFieldPtr            = errors.New("injectable field is not a pointer")
+	ErrFieldStruct         = errors.New("recursive field is not a struct or a pointer to a struct")
 	ErrResolveField        = errors.New("unable to resolve service for field")
+	ErrInvalidFunc         = errors.New("invalid function: expected a non-nil func value")
+	ErrParamPtr            = errors.New("invocable parameter is not a pointer")
+	ErrResolveParam        = errors.New("unable to resolve service for parameter")
+	ErrInvalidFactory      = errors.New("invalid factory: expected a func returning *T or (*T, error)")
+	ErrFactory             = errors.New("service factory failed")
+	ErrPostConstruct       = errors.New("service post-construct hook failed")
+	ErrValidate            = errors.New("service validation failed")
+	ErrCircularDependency  = errors.New("circular dependency detected")
+	ErrCaptiveDependency   = errors.New("captive dependency detected")
+	ErrDependencyFailed    = errors.New("unable to build service because a dependency failed")
 	ErrEmptyScope          = errors.New("scope is required but not provided")
+	ErrScopeExists         = errors.New("scope already exists")
+	ErrScopeNotFound       = errors.New("scope not found")
 	ErrTransientInstance   = errors.New("transient lifetime does not support pre-initialized instances")
+	ErrPooledInstance      = errors.New("pooled lifetime does not support pre-initialized instances")
+	ErrRefresh             = errors.New("refresh is only supported by singletons created by the registry")
+	ErrPrototypeTemplate   = errors.New("prototype lifetime requires a template instance")
+	ErrNilClone            = errors.New("clone returned a nil instance")
+	ErrNotPooled           = errors.New("service is not registered with a pooled lifetime")
+	ErrDispose             = errors.New("unable to dispose service instance")
+	ErrPanic               = errors.New("service panicked")
+	ErrAppStart            = errors.New("application failed to start")
+	ErrAppStop             = errors.New("application failed to stop")
+	ErrRunnerPanic         = errors.New("runner panicked")
+	ErrRunnerStop          = errors.New("runners did not stop in time")
+	ErrUnhealthy           = errors.New("service is unhealthy")
 )
@@ -0,0 +1,200 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// RegisterFactory registers a factory function creating instances of a type with a specified lifetime to the global
+// registry. Returns an error if the type is already registered or the factory is invalid.
+//
+// The factory must be a function returning *T, or *T and an error. Its parameters are resolved from the registry
+// when an instance is created, and every parameter must be a pointer to a registered service.
+//
+// The optFuncs parameter allows for optional configuration of the registration, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for scoped instances. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for thread-local instances. Optional and defaults
+//     to the current goroutine ID if not provided and the lifetime is ThreadLocal.
+//
+// Example:
+//
+//	err := needle.RegisterFactory[Database](needle.Singleton, func(cfg *Config) (*Database, error) {
+//	    return OpenDatabase(cfg.ConnectionString)
+//	})
+//	if err != nil {
+//	    ...
+//	}
+func RegisterFactory[T any](lifetime Lifetime, factory any, optFuncs ...ResolutionOptionFunc) error {
+	ensureGlobalRegistryInitialized()
+
+	return RegisterFactoryToRegistry[T](globalRegistry, lifetime, factory, optFuncs...)
+}
+
+// RegisterFactoryToRegistry registers a factory function creating instances of a type with a specified lifetime
+// to the registry. Returns an error if the type is already registered or the factory is invalid.
+//
+// The factory must be a function returning *T, or *T and an error. Its parameters are resolved from the registry
+// when an instance is created, and every parameter must be a pointer to a registered service.
+//
+// The optFuncs parameter allows for optional configuration of the registration, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for scoped instances. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for thread-local instances. Optional and defaults
+//     to the current goroutine ID if not provided and the lifetime is ThreadLocal.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	err := needle.RegisterFactoryToRegistry[Database](registry, needle.Singleton, func(cfg *Config) *Database {
+//	    return &Database{ConnectionString: cfg.ConnectionString}
+//	})
+//	if err != nil {
+//	    ...
+//	}
+func RegisterFactoryToRegistry[T any](
+	registry *Registry,
+	lifetime Lifetime,
+	factory any,
+	optFuncs ...ResolutionOptionFunc,
+) error {
+	typ := reflect.TypeFor[T]()
+
+	factoryValue := reflect.ValueOf(factory)
+	if !isFactoryOf(factoryValue, typ) {
+		return fmt.Errorf("%w: %T", ErrInvalidFactory, factory)
+	}
+
+	opt := newResolutionOptions(optFuncs...)
+
+	if lifetime == Scoped && opt.scope == "" {
+		return ErrEmptyScope
+	}
+
+	if lifetime == ThreadLocal && opt.threadID == "" {
+		opt.threadID = internal.GetGoroutineID()
+	}
+
+	entry := serviceEntry{typ: typ, lifetime: lifetime, factory: factoryValue} //nolint:exhaustruct
+
+	return registry.register(entry, reflect.Value{}, opt)
+}
+
+// isFactoryOf reports whether a value is a non-nil, non-variadic function returning *T, or *T and an error.
+func isFactoryOf(factory reflect.Value, typ reflect.Type) bool {
+	if factory.Kind() != reflect.Func || factory.IsNil() {
+		return false
+	}
+
+	factoryType := factory.Type()
+	if factoryType.IsVariadic() || factoryType.NumOut() == 0 || factoryType.NumOut() > 2 {
+		return false
+	}
+
+	if factoryType.Out(0) != reflect.PointerTo(typ) {
+		return false
+	}
+
+	return factoryType.NumOut() == 1 || factoryType.Out(1) == errorType
+}
+
+// factoryDependencies returns the services picked from the parameters of a factory: the pick function returns the
+// service injected for a pointer to a type, or nil if none is picked.
+func factoryDependencies(factoryType reflect.Type, pick func(reflect.Type) reflect.Type) []reflect.Type {
+	deps := make([]reflect.Type, 0, factoryType.NumIn())
+
+	for idx := range factoryType.NumIn() {
+		param := factoryType.In(idx)
+		if param.Kind() != reflect.Ptr {
+			continue
+		}
+
+		if dep := pick(param.Elem()); dep != nil && !slices.Contains(deps, dep) {
+			deps = append(deps, dep)
+		}
+	}
+
+	return deps
+}
+
+// construct creates a new instance of a service, either by copying its template, by calling its factory, or by
+// allocating it and injecting its fields, then runs its PostConstruct and Validate hooks and notifies the
+// observers. Returns an error if the service depends on itself, its dependencies cannot be resolved or a hook
+// fails.
+func construct(registry *Registry, entry serviceEntry, opt *ResolutionOptions) (reflect.Value, error) {
+	if slices.Contains(opt.chain, entry.typ) {
+		return reflect.Value{}, newResolutionError(registry, entry.typ, entry.lifetime, opt, ErrCircularDependency)
+	}
+
+	opt = opt.withDependent(entry.typ)
+
+	var (
+		value   reflect.Value
+		args    []reflect.Value
+		elapsed time.Duration
+		err     error
+	)
+
+	if entry.template.IsValid() {
+		if value, err = clonePrototype(entry); err != nil {
+			registry.observeCreate(entry, opt, 0, err)
+
+			return reflect.Value{}, err
+		}
+	} else if entry.factory.IsValid() {
+		if args, err = resolveArgs(registry, entry.factory.Type(), opt); err != nil {
+			return reflect.Value{}, err
+		}
+
+		_, span := registry.startSpan(opt, SpanFactory, entry.typ, entry.lifetime)
+		start := time.Now()
+		value, err = registry.callFactory(opt.Context(), entry, args)
+		elapsed = time.Since(start)
+
+		span.End(err)
+
+		if err != nil {
+			registry.observeCreate(entry, opt, elapsed, err)
+
+			return reflect.Value{}, err
+		}
+	} else {
+		value = reflect.New(entry.typ)
+
+		if internal.IsStructType(entry.typ) {
+			if err = injectStruct(registry, value.Elem(), opt, map[reflect.Type]bool{entry.typ: true}); err != nil {
+				return reflect.Value{}, err
+			}
+		}
+	}
+
+	start := time.Now()
+	err = initialize(value)
+	elapsed += time.Since(start)
+
+	registry.observeCreate(entry, opt, elapsed, err)
+
+	if err != nil {
+		return reflect.Value{}, err
+	}
+
+	return value, nil
+}
+
+// callFactory calls the factory of a service with its resolved arguments.
+func callFactory(entry serviceEntry, args []reflect.Value) (reflect.Value, error) {
+	results := entry.factory.Call(args)
+	if len(results) == 2 && !results[1].IsNil() {
+		err, _ := results[1].Interface().(error)
+
+		return reflect.Value{}, fmt.Errorf("%w %s: %w", ErrFactory, entry.name, err)
+	}
+
+	return results[0], nil
+}
@@ -0,0 +1,126 @@
+package needle_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedle_RegisterFactory(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Config struct{ dsn string }
+
+	type Database struct{ dsn string }
+
+	calls := 0
+
+	require.NoError(t, needle.RegisterSingletonInstance(&Config{dsn: "db://test"}))
+	require.NoError(t, needle.RegisterFactory[Database](needle.Singleton, func(cfg *Config) *Database {
+		calls++
+
+		return &Database{dsn: cfg.dsn}
+	}))
+
+	for range 2 {
+		val, err := needle.Resolve[Database]()
+		require.NoError(t, err)
+		assert.Equal(t, "db://test", val.dsn)
+	}
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestNeedle_RegisterFactoryToRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{ id int }
+
+	registry := needle.NewRegistry()
+	errFactory := errors.New("factory failed") //nolint:err113
+	next := 0
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testStruct](registry, needle.Transient,
+		func() (*testStruct, error) {
+			next++
+			if next > 2 {
+				return nil, errFactory
+			}
+
+			return &testStruct{id: next}, nil
+		}))
+
+	first, err := needle.ResolveFromRegistry[testStruct](registry)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first.id)
+
+	second, err := needle.ResolveFromRegistry[testStruct](registry)
+	require.NoError(t, err)
+	assert.Equal(t, 2, second.id)
+
+	_, err = needle.ResolveFromRegistry[testStruct](registry)
+	require.ErrorIs(t, err, needle.ErrFactory)
+	require.ErrorIs(t, err, errFactory)
+}
+
+func TestNeedle_RegisterFactoryInvalid(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{}
+
+	invalid := []any{
+		nil,
+		"not a function",
+		func() testStruct { return testStruct{} },
+		func() (*testStruct, int) { return nil, 0 },
+		func(...*testStruct) *testStruct { return nil },
+	}
+
+	for _, factory := range invalid {
+		require.ErrorIs(t, needle.RegisterFactory[testStruct](needle.Singleton, factory), needle.ErrInvalidFactory)
+	}
+}
+
+func TestNeedle_ResolveInjectsRegisteredTypes(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Logger struct{ prefix string }
+
+	type Service struct {
+		Logger *Logger `needle:"inject"`
+	}
+
+	require.NoError(t, needle.RegisterSingletonInstance(&Logger{prefix: "INFO"}))
+	require.NoError(t, needle.Register[Service](needle.Transient))
+
+	val, err := needle.Resolve[Service]()
+	require.NoError(t, err)
+	require.NotNil(t, val.Logger)
+	assert.Equal(t, "INFO", val.Logger.prefix)
+}
+
+type testNeedleCircularA struct {
+	B *testNeedleCircularB `needle:"inject"`
+}
+
+type testNeedleCircularB struct {
+	A *testNeedleCircularA `needle:"inject"`
+}
+
+func TestNeedle_ResolveCircularDependency(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	require.NoError(t, needle.Register[testNeedleCircularA](needle.Singleton))
+	require.NoError(t, needle.Register[testNeedleCircularB](needle.Singleton))
+
+	_, err := needle.Resolve[testNeedleCircularA]()
+	require.ErrorIs(t, err, needle.ErrCircularDependency)
+
+	var resErr *needle.ResolutionError
+
+	require.ErrorAs(t, err, &resErr)
+	assert.Len(t, resErr.Chain, 2)
+}
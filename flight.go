@@ -0,0 +1,117 @@
+package needle
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// flightKey identifies the creation of a retained instance by its service, lifetime and key.
+type flightKey struct {
+	typ      reflect.Type
+	lifetime Lifetime
+	key      string
+}
+
+// flight is the creation of a retained instance, shared by the resolutions looking for the instance meanwhile.
+type flight struct {
+	done  chan struct{}
+	value any
+	err   error
+	waits *flight // creation awaited by the resolution creating this instance, nil if none.
+}
+
+// reaches reports whether the flight is the target, or awaits it, directly or through other flights.
+// The caller must hold the registry lock.
+func (f *flight) reaches(target *flight) bool {
+	for current := f; current != nil; current = current.waits {
+		if current == target {
+			return true
+		}
+	}
+
+	return false
+}
+
+// createOnce creates a retained instance with create, unless the instance is already being created, in which case
+// the creation is awaited and its result shared. Reports whether an instance created by another resolution was
+// returned. Failures are shared with the awaiting resolutions but not kept, so the next resolution tries again.
+//
+// A resolution whose own creation is awaited by the creation it would join creates the instance on its own instead,
+// so that circular dependencies fail with ErrCircularDependency rather than deadlocking.
+func (r *Registry) createOnce(
+	key flightKey,
+	opt *ResolutionOptions,
+	create func(opt *ResolutionOptions) (any, bool, error),
+) (any, bool, error) {
+	r.lock.Lock()
+
+	current, found := r.flights[key]
+	if found && current.reaches(opt.flight) {
+		r.lock.Unlock()
+
+		return create(opt)
+	}
+
+	if !found {
+		current = &flight{done: make(chan struct{}), value: nil, err: nil, waits: nil}
+		r.flights[key] = current
+	}
+
+	if opt.flight != nil {
+		opt.flight.waits = current
+
+		defer func() {
+			r.lock.Lock()
+			opt.flight.waits = nil
+			r.lock.Unlock()
+		}()
+	}
+
+	r.lock.Unlock()
+
+	if found {
+		<-current.done
+
+		return current.value, current.err == nil, current.err
+	}
+
+	defer func() {
+		r.lock.Lock()
+		delete(r.flights, key)
+		r.lock.Unlock()
+
+		close(current.done)
+	}()
+
+	var reused bool
+
+	current.value, reused, current.err = create(opt.withFlight(current))
+
+	return current.value, reused, current.err
+}
+
+// callFactory calls the factory of a service, retrying failed calls as configured with WithFactoryRetry until the
+// context of the resolution is done.
+func (r *Registry) callFactory(ctx context.Context, entry serviceEntry, args []reflect.Value) (reflect.Value, error) {
+	backoff := r.retry.minBackoff
+
+	for attempt := 1; ; attempt++ {
+		value, err := callFactory(entry, args)
+		if err == nil || attempt >= r.retry.attempts {
+			return value, err
+		}
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return value, err
+		case <-timer.C:
+		}
+
+		backoff = min(backoff*2, r.retry.maxBackoff) //nolint:mnd
+	}
+}
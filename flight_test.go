@@ -0,0 +1,191 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedleFlightClient struct {
+	id int64
+}
+
+type testNeedleFlightConfig struct{}
+
+func resolveConcurrently[T any](t *testing.T, registry *needle.Registry, optFuncs ...needle.ResolutionOptionFunc) []*T {
+	t.Helper()
+
+	const resolutions = 50
+
+	var (
+		waitGroup sync.WaitGroup
+		start     = make(chan struct{})
+		results   = make([]*T, resolutions)
+		errs      = make([]error, resolutions)
+	)
+
+	for idx := range resolutions {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			<-start
+
+			results[idx], errs[idx] = needle.ResolveFromRegistry[T](registry, optFuncs...)
+		}()
+	}
+
+	close(start)
+	waitGroup.Wait()
+
+	for _, err := range errs {
+		require.NoError(t, err)
+	}
+
+	return results
+}
+
+func TestNeedle_ConcurrentFirstResolutionCreatesOnce(t *testing.T) {
+	for _, lifetime := range []needle.Lifetime{needle.Singleton, needle.Scoped} {
+		t.Run(lifetime.String(), func(t *testing.T) {
+			registry := needle.NewRegistry()
+
+			var created atomic.Int64
+
+			require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleFlightClient](registry, lifetime,
+				func() *testNeedleFlightClient {
+					time.Sleep(20 * time.Millisecond)
+
+					return &testNeedleFlightClient{id: created.Add(1)}
+				}, needle.WithScope("request1")))
+
+			clients := resolveConcurrently[testNeedleFlightClient](t, registry, needle.WithScope("request1"))
+
+			assert.Equal(t, int64(1), created.Load())
+
+			for _, client := range clients {
+				assert.Same(t, clients[0], client)
+			}
+		})
+	}
+}
+
+func TestNeedle_ConstructionDoesNotHoldLock(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterToRegistry[testNeedleFlightConfig](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleFlightClient](registry, needle.Singleton,
+		func() (*testNeedleFlightClient, error) {
+			// resolving and registering from a factory would deadlock if the registry were locked
+			if _, err := needle.ResolveFromRegistry[testNeedleFlightConfig](registry); err != nil {
+				return nil, err
+			}
+
+			return &testNeedleFlightClient{id: 1}, needle.RegisterInstanceToRegistry(registry, needle.Singleton,
+				&testNeedleShutdownLog{})
+		}))
+
+	client, err := needle.ResolveFromRegistry[testNeedleFlightClient](registry)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), client.id)
+}
+
+func TestNeedle_FailedConstructionNotCached(t *testing.T) {
+	registry := needle.NewRegistry()
+	errUnavailable := errors.New("unavailable") //nolint:err113
+
+	var calls atomic.Int64
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleFlightClient](registry, needle.Singleton,
+		func() (*testNeedleFlightClient, error) {
+			if calls.Add(1) == 1 {
+				return nil, errUnavailable
+			}
+
+			return &testNeedleFlightClient{id: calls.Load()}, nil
+		}))
+
+	_, err := needle.ResolveFromRegistry[testNeedleFlightClient](registry)
+	require.ErrorIs(t, err, errUnavailable)
+
+	client, err := needle.ResolveFromRegistry[testNeedleFlightClient](registry)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), client.id)
+}
+
+func TestNeedle_FactoryRetry(t *testing.T) {
+	registry := needle.NewRegistry(needle.WithFactoryRetry(3, time.Millisecond, 5*time.Millisecond))
+	errUnavailable := errors.New("unavailable") //nolint:err113
+
+	var calls atomic.Int64
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleFlightClient](registry, needle.Singleton,
+		func() (*testNeedleFlightClient, error) {
+			if calls.Add(1) < 3 {
+				return nil, errUnavailable
+			}
+
+			return &testNeedleFlightClient{id: calls.Load()}, nil
+		}))
+
+	clients := resolveConcurrently[testNeedleFlightClient](t, registry)
+	assert.Equal(t, int64(3), clients[0].id)
+	assert.Equal(t, int64(3), calls.Load())
+}
+
+func TestNeedle_FactoryRetryStopsWhenContextDone(t *testing.T) {
+	registry := needle.NewRegistry(needle.WithFactoryRetry(5, time.Hour, time.Hour))
+	errUnavailable := errors.New("unavailable") //nolint:err113
+
+	var calls atomic.Int64
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleFlightClient](registry, needle.Singleton,
+		func() (*testNeedleFlightClient, error) {
+			calls.Add(1)
+
+			return nil, errUnavailable
+		}))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := needle.ResolveFromRegistry[testNeedleFlightClient](registry, needle.WithContext(ctx))
+	require.ErrorIs(t, err, errUnavailable)
+	assert.Equal(t, int64(1), calls.Load())
+}
+
+func TestNeedle_ConcurrentCircularResolutionsFail(t *testing.T) {
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[testNeedleCircularA](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleCircularB](registry, needle.Singleton))
+
+	var waitGroup sync.WaitGroup
+
+	for range 10 {
+		waitGroup.Add(2)
+
+		go func() {
+			defer waitGroup.Done()
+
+			_, err := needle.ResolveFromRegistry[testNeedleCircularA](registry)
+			assert.ErrorIs(t, err, needle.ErrCircularDependency)
+		}()
+
+		go func() {
+			defer waitGroup.Done()
+
+			_, err := needle.ResolveFromRegistry[testNeedleCircularB](registry)
+			assert.ErrorIs(t, err, needle.ErrCircularDependency)
+		}()
+	}
+
+	waitGroup.Wait()
+}
@@ -0,0 +1,173 @@
+package needle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+const defaultCheckTimeout = 5 * time.Second
+
+// HealthChecker is implemented by services reporting their health, such as database pools and queue clients.
+type HealthChecker interface {
+	CheckHealth(ctx context.Context) error
+}
+
+// HealthOptions holds configuration options for checking the health of a registry.
+type HealthOptions struct {
+	checkTimeout time.Duration
+}
+
+// HealthOptionFunc is a function that modifies a HealthOptions struct.
+type HealthOptionFunc func(*HealthOptions)
+
+// WithCheckTimeout sets the maximum time spent checking the health of a single service instance. Defaults to 5s.
+//
+// Example:
+//
+//	report, err := registry.Health(ctx, needle.WithCheckTimeout(time.Second))
+func WithCheckTimeout(timeout time.Duration) HealthOptionFunc {
+	return func(o *HealthOptions) {
+		o.checkTimeout = timeout
+	}
+}
+
+// newHealthOptions creates a new HealthOptions struct from the provided option functions.
+func newHealthOptions(optFuncs ...HealthOptionFunc) *HealthOptions {
+	opt := &HealthOptions{checkTimeout: defaultCheckTimeout}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	return opt
+}
+
+// HealthReport describes the health of the services held by a registry.
+type HealthReport struct {
+	Healthy  bool            // Whether every checked service is healthy.
+	Services []ServiceHealth // Checked instances, sorted by name, scope and thread ID.
+	Duration time.Duration   // Total duration of the checks.
+}
+
+// ServiceHealth describes the health of a service instance.
+type ServiceHealth struct {
+	Name     string        // Display name of the service.
+	Type     reflect.Type  // Type of the service.
+	Lifetime Lifetime      // Lifetime of the service.
+	Scope    string        // Scope holding the instance, empty unless the service is Scoped.
+	ThreadID string        // Thread ID holding the instance, empty unless the service is ThreadLocal.
+	Duration time.Duration // Time spent checking the instance.
+	TimedOut bool          // Whether the check was abandoned because a deadline was exceeded.
+	Err      error         // Error reported by the check, nil when the instance is healthy.
+}
+
+// Health checks the health of the services held by the global registry.
+// See Registry.Health for details.
+func Health(ctx context.Context, optFuncs ...HealthOptionFunc) (HealthReport, error) {
+	ensureGlobalRegistryInitialized()
+
+	return globalRegistry.Health(ctx, optFuncs...)
+}
+
+// Health concurrently checks every created instance implementing HealthChecker, whether singleton, scoped or
+// thread-local. Lazily created services that have not been created yet are not created and not checked.
+// Returns a report of the checked instances and the joined errors of every unhealthy instance.
+//
+// Each check is bound by the context and by the timeout set with WithCheckTimeout.
+//
+// Example:
+//
+//	report, err := registry.Health(ctx, needle.WithCheckTimeout(time.Second))
+//	for _, service := range report.Services {
+//	    log.Printf("%s healthy=%t", service.Name, service.Err == nil)
+//	}
+func (r *Registry) Health(ctx context.Context, optFuncs ...HealthOptionFunc) (HealthReport, error) {
+	opt := newHealthOptions(optFuncs...)
+	start := time.Now()
+
+	r.lock.RLock()
+	instances := r.heldInstances()
+	r.lock.RUnlock()
+
+	var (
+		waitGroup sync.WaitGroup
+		mutex     sync.Mutex
+		services  []ServiceHealth
+	)
+
+	for _, inst := range instances {
+		checker, ok := inst.value.Interface().(HealthChecker)
+		if !ok {
+			continue
+		}
+
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			checkCtx, cancel := context.WithTimeout(ctx, opt.checkTimeout)
+			defer cancel()
+
+			checkStart := time.Now()
+			err := callWithin(checkCtx, func() error { return checker.CheckHealth(checkCtx) })
+			name := internal.ServiceName(inst.typ)
+
+			if err != nil {
+				err = fmt.Errorf("%w: %s: %w", ErrUnhealthy, name, err)
+			}
+
+			mutex.Lock()
+			defer mutex.Unlock()
+
+			services = append(services, ServiceHealth{
+				Name:     name,
+				Type:     inst.typ,
+				Lifetime: inst.lifetime,
+				Scope:    inst.scope,
+				ThreadID: inst.threadID,
+				Duration: time.Since(checkStart),
+				TimedOut: errors.Is(err, context.DeadlineExceeded),
+				Err:      err,
+			})
+		}()
+	}
+
+	waitGroup.Wait()
+
+	slices.SortFunc(services, cmpHealth)
+
+	report := HealthReport{Healthy: true, Services: services, Duration: time.Since(start)}
+
+	var errs []error
+
+	for _, service := range services {
+		if service.Err != nil {
+			report.Healthy = false
+
+			errs = append(errs, service.Err)
+		}
+	}
+
+	return report, errors.Join(errs...)
+}
+
+// cmpHealth orders service health results by name, scope and thread ID.
+func cmpHealth(a, b ServiceHealth) int {
+	if a.Name != b.Name {
+		return strings.Compare(a.Name, b.Name)
+	}
+
+	if a.Scope != b.Scope {
+		return strings.Compare(a.Scope, b.Scope)
+	}
+
+	return strings.Compare(a.ThreadID, b.ThreadID)
+}
@@ -0,0 +1,87 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNeedleHealth = errors.New("connection refused")
+
+type testNeedleHealthDatabase struct{}
+
+func (d *testNeedleHealthDatabase) CheckHealth(context.Context) error {
+	return nil
+}
+
+type testNeedleHealthQueue struct{}
+
+func (q *testNeedleHealthQueue) CheckHealth(context.Context) error {
+	return errTestNeedleHealth
+}
+
+type testNeedleHealthCache struct{}
+
+func (c *testNeedleHealthCache) CheckHealth(ctx context.Context) error {
+	<-ctx.Done()
+
+	return ctx.Err()
+}
+
+type testNeedleHealthSession struct{}
+
+func (s *testNeedleHealthSession) CheckHealth(context.Context) error {
+	panic("boom")
+}
+
+func TestNeedle_Health(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	require.NoError(t, needle.RegisterSingletonInstance(&testNeedleHealthDatabase{}))
+	require.NoError(t, needle.Register[testNeedleHealthQueue](needle.Singleton))
+	require.NoError(t, needle.RegisterSingletonInstance(&testNeedleShutdownLog{})) //nolint:exhaustruct
+
+	report, err := needle.Health(context.Background())
+	require.NoError(t, err)
+	assert.True(t, report.Healthy)
+	require.Len(t, report.Services, 1)
+	assert.Equal(t, "github.com/goplexhq/needle_test.testNeedleHealthDatabase", report.Services[0].Name)
+	assert.Equal(t, needle.Singleton, report.Services[0].Lifetime)
+
+	_, err = needle.Resolve[testNeedleHealthQueue]()
+	require.NoError(t, err)
+
+	report, err = needle.Health(context.Background())
+	require.ErrorIs(t, err, needle.ErrUnhealthy)
+	require.ErrorIs(t, err, errTestNeedleHealth)
+	assert.False(t, report.Healthy)
+	require.Len(t, report.Services, 2)
+	require.NoError(t, report.Services[0].Err)
+	require.ErrorIs(t, report.Services[1].Err, errTestNeedleHealth)
+}
+
+func TestNeedle_Health_TimeoutAndPanic(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testNeedleHealthCache{}))
+	require.NoError(t, needle.RegisterScopedInstanceToRegistry(
+		registry, &testNeedleHealthSession{}, needle.WithScope("request1")))
+
+	report, err := registry.Health(context.Background(), needle.WithCheckTimeout(10*time.Millisecond))
+	require.ErrorIs(t, err, needle.ErrUnhealthy)
+	require.Len(t, report.Services, 2)
+
+	cache, session := report.Services[0], report.Services[1]
+
+	assert.True(t, cache.TimedOut)
+	require.ErrorIs(t, cache.Err, context.DeadlineExceeded)
+	assert.Equal(t, "request1", session.Scope)
+	assert.False(t, session.TimedOut)
+	require.ErrorIs(t, session.Err, needle.ErrPanic)
+	require.ErrorContains(t, session.Err, "boom")
+}
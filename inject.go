@@ -3,14 +3,16 @@ package needle
 import (
 	"fmt"
 	"reflect"
+	"strings"
 	"unsafe"
 
 	"github.com/goplexhq/needle/internal"
 )
 
 const (
-	injectTagKey   = "needle"
-	injectTagValue = "inject"
+	injectTagKey     = "needle"
+	injectTagValue   = "inject"
+	injectTagRecurse = "recurse"
 )
 
 // InjectStructFields injects dependencies into the fields of a struct using the global registry.
@@ -23,7 +25,10 @@ const (
 // - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
 //
 // Fields must be annotated with `needle:"inject"` for the needle framework to inject dependencies into them.
-// Additionally, the field must be a pointer to a struct.
+// Additionally, the field must be a pointer to the registered service type.
+//
+// Anonymous embedded structs, both value and pointer, are traversed recursively. Nested struct fields are
+// traversed only when annotated with `needle:"inject,recurse"`.
 //
 // Example:
 //
@@ -54,7 +59,10 @@ func InjectStructFields[Dest any](dest *Dest, optFuncs ...ResolutionOptionFunc)
 // - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
 //
 // Fields must be annotated with `needle:"inject"` for the needle framework to inject dependencies into them.
-// Additionally, the field must be a pointer to a struct.
+// Additionally, the field must be a pointer to the registered service type.
+//
+// Anonymous embedded structs, both value and pointer, are traversed recursively. Nested struct fields are
+// traversed only when annotated with `needle:"inject,recurse"`.
 //
 // Example:
 //
@@ -79,19 +87,136 @@ func InjectStructFieldsFromRegistry[Dest any](registry *Registry, dest *Dest, op
 		return fmt.Errorf("%w: %s", ErrInvalidDestType, targetName)
 	}
 
-	targetValue := reflect.ValueOf(dest).Elem()
+	return injectStructValue(registry, reflect.ValueOf(dest).Elem(), newResolutionOptions(optFuncs...))
+}
+
+// InjectValue injects dependencies into the fields of a struct discovered at runtime using the global registry.
+// The dest parameter must be a non-nil pointer to a struct. Returns an error if the injection fails.
+//
+// The optFuncs parameter allows for optional configuration of the injection, such as setting a scope or thread ID.
+//
+// Available options:
+// - WithScope(scope string): Sets a scope for resolving scoped dependencies.
+// - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//
+// Example:
+//
+//	dest := reflect.New(handlerType).Interface()
+//	err := needle.InjectValue(dest)
+//	if err != nil {
+//	    ...
+//	}
+func InjectValue(dest any, optFuncs ...ResolutionOptionFunc) error {
+	ensureGlobalRegistryInitialized()
+
+	return InjectValueFromRegistry(globalRegistry, dest, optFuncs...)
+}
+
+// InjectValueFromRegistry injects dependencies into the fields of a struct discovered at runtime using the
+// specified registry. The dest parameter must be a non-nil pointer to a struct. Returns an error if the injection
+// fails.
+//
+// The optFuncs parameter allows for optional configuration of the injection, such as setting a scope or thread ID.
+//
+// Available options:
+// - WithScope(scope string): Sets a scope for resolving scoped dependencies.
+// - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	dest := reflect.New(handlerType).Interface()
+//	err := needle.InjectValueFromRegistry(registry, dest)
+//	if err != nil {
+//	    ...
+//	}
+func InjectValueFromRegistry(registry *Registry, dest any, optFuncs ...ResolutionOptionFunc) error {
+	destValue := reflect.ValueOf(dest)
+	if !internal.IsPointerValue(destValue) || destValue.IsNil() || !internal.IsStructType(destValue.Type().Elem()) {
+		return fmt.Errorf("%w: %T", ErrInvalidDestType, dest)
+	}
+
+	return injectStructValue(registry, destValue.Elem(), newResolutionOptions(optFuncs...))
+}
+
+// injectStructValue injects dependencies into the fields of an addressable struct value, then runs its
+// PostConstruct and Validate hooks.
+func injectStructValue(registry *Registry, targetValue reflect.Value, opt *ResolutionOptions) error {
 	initializePointerValue(&targetValue)
 
-	opt := newResolutionOptions(optFuncs...)
+	targetType := targetValue.Type()
 
-	for idx := range targetType.NumField() {
-		fieldType := targetType.Field(idx)
-		if fieldType.Tag.Get(injectTagKey) != injectTagValue {
-			continue
+	err := injectStruct(registry, targetValue, opt.withDependent(targetType), map[reflect.Type]bool{targetType: true})
+	if err != nil {
+		return err
+	}
+
+	return initialize(targetValue.Addr())
+}
+
+// initializePointerValue ensures the pointer value is not nil by initializing it.
+func initializePointerValue(value *reflect.Value) {
+	if internal.IsPointerValue(*value) && value.IsNil() {
+		exposeValue(*value).Set(reflect.New(value.Type().Elem()))
+	}
+}
+
+// exposeValue returns a settable view of an addressable value, including values of unexported fields.
+func exposeValue(value reflect.Value) reflect.Value {
+	if value.CanSet() {
+		return value
+	}
+
+	return reflect.NewAt(value.Type(), unsafe.Pointer(value.UnsafeAddr())).Elem()
+}
+
+// parseInjectTag reports whether a struct field is annotated for injection and whether it requests
+// recursive traversal, i.e. `needle:"inject"` or `needle:"inject,recurse"`.
+func parseInjectTag(tag reflect.StructTag) (bool, bool) {
+	name, options, _ := strings.Cut(tag.Get(injectTagKey), ",")
+	if name != injectTagValue {
+		return false, false
+	}
+
+	for _, option := range strings.Split(options, ",") {
+		if option == injectTagRecurse {
+			return true, true
 		}
+	}
+
+	return true, false
+}
 
-		fieldValue := targetValue.Field(idx)
-		if err := injectField(registry, fieldType, fieldValue, opt); err != nil {
+// injectStruct injects dependencies into the annotated fields of a struct value, descending into anonymous
+// embedded structs and into fields annotated with the recurse option.
+//
+// The visiting set holds the struct types on the current traversal path and guards against self-referential types.
+func injectStruct(
+	registry *Registry,
+	value reflect.Value,
+	opt *ResolutionOptions,
+	visiting map[reflect.Type]bool,
+) error {
+	structType := value.Type()
+
+	for idx := range structType.NumField() {
+		fieldType := structType.Field(idx)
+		fieldValue := value.Field(idx)
+
+		inject, recurse := parseInjectTag(fieldType.Tag)
+
+		var err error
+
+		switch {
+		case recurse:
+			err = injectNestedStruct(registry, fieldType, fieldValue, opt, visiting, true)
+		case inject:
+			err = injectField(registry, fieldType, fieldValue, opt)
+		case fieldType.Anonymous:
+			err = injectNestedStruct(registry, fieldType, fieldValue, opt, visiting, false)
+		}
+
+		if err != nil {
 			return err
 		}
 	}
@@ -99,44 +224,99 @@ func InjectStructFieldsFromRegistry[Dest any](registry *Registry, dest *Dest, op
 	return nil
 }
 
-// initializePointerValue ensures the pointer value is not nil by initializing it.
-func initializePointerValue(value *reflect.Value) {
-	if internal.IsPointerValue(*value) && value.IsNil() {
-		value.Set(reflect.New(value.Type().Elem()))
+// injectNestedStruct injects dependencies into a struct, or pointer to struct, held by a field of another struct.
+//
+// Nil pointers are initialized when the field explicitly requests recursion, or when the pointed-to struct has
+// fields to inject. Types already on the traversal path are skipped.
+func injectNestedStruct(
+	registry *Registry,
+	field reflect.StructField,
+	value reflect.Value,
+	opt *ResolutionOptions,
+	visiting map[reflect.Type]bool,
+	explicit bool,
+) error {
+	structType := field.Type
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+
+	if !internal.IsStructType(structType) {
+		if explicit {
+			return fmt.Errorf("%w: %s", ErrFieldStruct, field.Name)
+		}
+
+		return nil
+	}
+
+	if visiting[structType] {
+		return nil
 	}
+
+	if internal.IsPointerValue(value) {
+		if value.IsNil() {
+			if !explicit && !hasInjectableFields(structType, map[reflect.Type]bool{}) {
+				return nil
+			}
+
+			initializePointerValue(&value)
+		}
+
+		value = value.Elem()
+	}
+
+	visiting[structType] = true
+	defer delete(visiting, structType)
+
+	return injectStruct(registry, value, opt.withDependent(structType), visiting)
 }
 
-// injectField injects a dependency into a single struct field.
-func injectField(registry *Registry, field reflect.StructField, value reflect.Value, opt *ResolutionOptions) error {
-	if !internal.IsPointerValue(value) {
-		return fmt.Errorf("%w: %s", ErrFieldPtr, field.Name)
+// hasInjectableFields reports whether a struct type, or any struct it embeds or recurses into, has fields
+// annotated for injection.
+func hasInjectableFields(structType reflect.Type, seen map[reflect.Type]bool) bool {
+	if seen[structType] {
+		return false
 	}
 
-	elem := value.Type().Elem()
-	if internal.IsStructType(elem) {
-		name := internal.ServiceName(elem)
+	seen[structType] = true
+
+	for idx := range structType.NumField() {
+		field := structType.Field(idx)
 
-		entry, exists := registry.has(name)
-		if !exists {
-			return fmt.Errorf("%w: %s", ErrNotRegistered, name)
+		inject, recurse := parseInjectTag(field.Tag)
+		if inject && !recurse {
+			return true
 		}
 
-		if entry.lifetime == Scoped && opt.scope == "" {
-			return ErrEmptyScope
+		if !recurse && !field.Anonymous {
+			continue
 		}
 
-		if entry.lifetime == ThreadLocal && opt.threadID == "" {
-			opt.threadID = internal.GetGoroutineID()
+		nested := field.Type
+		if nested.Kind() == reflect.Ptr {
+			nested = nested.Elem()
 		}
 
-		entryValue, err := resolveName(registry, name, opt)
-		if err != nil {
-			return fmt.Errorf("%w %q: %w", ErrResolveField, field.Name, err)
+		if internal.IsStructType(nested) && hasInjectableFields(nested, seen) {
+			return true
 		}
+	}
+
+	return false
+}
+
+// injectField injects a dependency into a single struct field.
+func injectField(registry *Registry, field reflect.StructField, value reflect.Value, opt *ResolutionOptions) error {
+	if !internal.IsPointerValue(value) {
+		return fmt.Errorf("%w: %s", ErrFieldPtr, field.Name)
+	}
 
-		value = reflect.NewAt(value.Type(), unsafe.Pointer(value.UnsafeAddr())).Elem()
-		value.Set(reflect.ValueOf(entryValue))
+	entryValue, err := resolveDependency(registry, value.Type().Elem(), opt)
+	if err != nil {
+		return fmt.Errorf("%w %q: %w", ErrResolveField, field.Name, err)
 	}
 
+	exposeValue(value).Set(reflect.ValueOf(entryValue))
+
 	return nil
 }
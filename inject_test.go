@@ -1,6 +1,7 @@
 package needle_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/goplexhq/needle"
@@ -74,3 +75,149 @@ func TestNeedle_InjectStructFieldsNotRegistered(t *testing.T) {
 	require.NoError(t, needle.InjectStructFields(&testStruct))
 	assert.Nil(t, testStruct.Dep)
 }
+
+func TestNeedle_InjectStructFieldsEmbedded(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Logger struct{ name string }
+
+	type Metrics struct{ name string }
+
+	type BaseHandler struct {
+		Logger *Logger `needle:"inject"`
+	}
+
+	type baseMetrics struct {
+		metrics *Metrics `needle:"inject"`
+	}
+
+	type Handler struct {
+		BaseHandler
+		*baseMetrics
+	}
+
+	require.NoError(t, needle.RegisterSingletonInstance(&Logger{name: "logger"}))
+	require.NoError(t, needle.RegisterSingletonInstance(&Metrics{name: "metrics"}))
+
+	var handler Handler
+
+	require.NoError(t, needle.InjectStructFields(&handler))
+	require.NotNil(t, handler.Logger)
+	assert.Equal(t, "logger", handler.Logger.name)
+	require.NotNil(t, handler.baseMetrics)
+	require.NotNil(t, handler.metrics)
+	assert.Equal(t, "metrics", handler.metrics.name)
+}
+
+func TestNeedle_InjectStructFieldsRecurse(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Dep struct{ name string }
+
+	type Nested struct {
+		Dep *Dep `needle:"inject"`
+	}
+
+	type TestStruct struct {
+		Nested  Nested  `needle:"inject,recurse"`
+		Pointer *Nested `needle:"inject,recurse"`
+		Skipped Nested
+	}
+
+	require.NoError(t, needle.RegisterSingletonInstance(&Dep{name: "myDep"}))
+
+	var testStruct TestStruct
+
+	require.NoError(t, needle.InjectStructFields(&testStruct))
+	require.NotNil(t, testStruct.Nested.Dep)
+	assert.Equal(t, "myDep", testStruct.Nested.Dep.name)
+	require.NotNil(t, testStruct.Pointer)
+	require.NotNil(t, testStruct.Pointer.Dep)
+	assert.Equal(t, "myDep", testStruct.Pointer.Dep.name)
+	assert.Nil(t, testStruct.Skipped.Dep)
+
+	type InvalidStruct struct {
+		Value int `needle:"inject,recurse"`
+	}
+
+	require.ErrorIs(t, needle.InjectStructFields(&InvalidStruct{}), needle.ErrFieldStruct) //nolint:exhaustruct
+}
+
+type testNeedleInjectSelfReferential struct {
+	*testNeedleInjectSelfReferential
+
+	Next *testNeedleInjectSelfReferential `needle:"inject,recurse"`
+}
+
+func TestNeedle_InjectStructFieldsSelfReferential(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	var testStruct testNeedleInjectSelfReferential
+
+	require.NoError(t, needle.InjectStructFields(&testStruct))
+	assert.Nil(t, testStruct.testNeedleInjectSelfReferential)
+	assert.Nil(t, testStruct.Next)
+}
+
+func TestNeedle_InjectValue(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Dep struct{ name string }
+
+	type TestStruct struct {
+		Dep *Dep `needle:"inject"`
+	}
+
+	require.NoError(t, needle.RegisterSingletonInstance(&Dep{name: "myDep"}))
+
+	dest := reflect.New(reflect.TypeFor[TestStruct]()).Interface()
+
+	require.NoError(t, needle.InjectValue(dest))
+	assert.Equal(t, "myDep", dest.(*TestStruct).Dep.name) //nolint:forcetypeassert
+
+	require.ErrorIs(t, needle.InjectValue(TestStruct{}), needle.ErrInvalidDestType) //nolint:exhaustruct
+	require.ErrorIs(t, needle.InjectValue((*TestStruct)(nil)), needle.ErrInvalidDestType)
+	require.ErrorIs(t, needle.InjectValue(nil), needle.ErrInvalidDestType)
+}
+
+func TestNeedle_InjectValueFromRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Dep struct{ name string }
+
+	type TestStruct struct {
+		Dep *Dep `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &Dep{name: "myDep"}))
+
+	var testStruct TestStruct
+
+	require.NoError(t, needle.InjectValueFromRegistry(registry, &testStruct))
+	assert.Equal(t, "myDep", testStruct.Dep.name)
+}
+
+func TestNeedle_InjectStructFieldsNonStructTypes(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Dep struct{ name string }
+
+	type TestStruct struct {
+		Names *[]string `needle:"inject"`
+		Dep   **Dep     `needle:"inject"`
+	}
+
+	names := []string{"a", "b"}
+	dep := &Dep{name: "myDep"}
+
+	require.NoError(t, needle.RegisterSingletonInstance(&names))
+	require.NoError(t, needle.RegisterSingletonInstance(&dep))
+
+	var testStruct TestStruct
+
+	require.NoError(t, needle.InjectStructFields(&testStruct))
+	assert.Equal(t, names, *testStruct.Names)
+	assert.Same(t, dep, *testStruct.Dep)
+}
@@ -0,0 +1,115 @@
+package internal
+
+import (
+	"reflect"
+	"unsafe"
+)
+
+// copyKey identifies a pointer or map already copied, by type so that a struct and its first field stay distinct.
+type copyKey struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// DeepCopy returns a deep copy of a value. Pointers, slices, maps and interfaces are copied recursively, including
+// through unexported struct fields, and pointers shared within the value, including cyclic ones, stay shared within
+// the copy. Channels, functions and unsafe pointers are shared with the original.
+func DeepCopy(value reflect.Value) reflect.Value {
+	dst := reflect.New(value.Type()).Elem()
+	copyValue(dst, value, make(map[copyKey]reflect.Value))
+
+	return dst
+}
+
+// copyValue deeply copies src into the settable dst.
+func copyValue(dst, src reflect.Value, copies map[copyKey]reflect.Value) {
+	switch src.Kind() { //nolint:exhaustive
+	case reflect.Pointer:
+		if src.IsNil() {
+			return
+		}
+
+		key := copyKey{typ: src.Type(), ptr: src.Pointer()}
+		if copied, found := copies[key]; found {
+			dst.Set(copied)
+
+			return
+		}
+
+		copied := reflect.New(src.Type().Elem())
+		copies[key] = copied
+
+		copyValue(copied.Elem(), src.Elem(), copies)
+		dst.Set(copied)
+	case reflect.Struct:
+		if !src.CanAddr() {
+			addressable := reflect.New(src.Type()).Elem()
+			addressable.Set(src)
+			src = addressable
+		}
+
+		for idx := range src.NumField() {
+			copyValue(expose(dst.Field(idx)), expose(src.Field(idx)), copies)
+		}
+	case reflect.Array:
+		for idx := range src.Len() {
+			copyValue(dst.Index(idx), src.Index(idx), copies)
+		}
+	case reflect.Slice:
+		if src.IsNil() {
+			return
+		}
+
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		for idx := range src.Len() {
+			copyValue(copied.Index(idx), src.Index(idx), copies)
+		}
+
+		dst.Set(copied)
+	case reflect.Map:
+		if src.IsNil() {
+			return
+		}
+
+		key := copyKey{typ: src.Type(), ptr: src.Pointer()}
+		if copied, found := copies[key]; found {
+			dst.Set(copied)
+
+			return
+		}
+
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		copies[key] = copied
+
+		for iter := src.MapRange(); iter.Next(); {
+			mapKey := reflect.New(src.Type().Key()).Elem()
+			copyValue(mapKey, iter.Key(), copies)
+
+			mapValue := reflect.New(src.Type().Elem()).Elem()
+			copyValue(mapValue, iter.Value(), copies)
+
+			copied.SetMapIndex(mapKey, mapValue)
+		}
+
+		dst.Set(copied)
+	case reflect.Interface:
+		if src.IsNil() {
+			return
+		}
+
+		copied := reflect.New(src.Elem().Type()).Elem()
+		copyValue(copied, src.Elem(), copies)
+		dst.Set(copied)
+	default:
+		dst.Set(src)
+	}
+}
+
+// expose returns a settable view of an addressable value, including values of unexported fields.
+func expose(value reflect.Value) reflect.Value {
+	if value.CanSet() {
+		return value
+	}
+
+	return reflect.NewAt(value.Type(), unsafe.Pointer(value.UnsafeAddr())).Elem()
+}
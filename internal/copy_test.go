@@ -0,0 +1,53 @@
+package internal_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+type testCopyNode struct {
+	Name     string
+	next     *testCopyNode
+	tags     []string
+	attrs    map[string]any
+	children [2]*testCopyNode
+}
+
+func TestDeepCopy(t *testing.T) {
+	leaf := &testCopyNode{Name: "leaf"}
+	root := &testCopyNode{
+		Name:     "root",
+		tags:     []string{"a", "b"},
+		attrs:    map[string]any{"nested": map[string]int{"n": 1}, "node": testCopyNode{tags: []string{"c"}}},
+		children: [2]*testCopyNode{leaf, leaf},
+	}
+	root.next = root
+
+	clone, _ := internal.DeepCopy(reflect.ValueOf(root)).Interface().(*testCopyNode)
+
+	assert.NotSame(t, root, clone)
+	assert.Same(t, clone, clone.next)
+	assert.Equal(t, "root", clone.Name)
+	assert.NotSame(t, leaf, clone.children[0])
+	assert.Same(t, clone.children[0], clone.children[1])
+
+	clone.tags[0] = "z"
+	clone.attrs["nested"].(map[string]int)["n"] = 2
+	clone.children[0].Name = "copy"
+
+	assert.Equal(t, "a", root.tags[0])
+	assert.Equal(t, 1, root.attrs["nested"].(map[string]int)["n"])
+	assert.Equal(t, []string{"c"}, clone.attrs["node"].(testCopyNode).tags)
+	assert.Equal(t, "leaf", leaf.Name)
+}
+
+func TestDeepCopyNil(t *testing.T) {
+	var node *testCopyNode
+
+	assert.True(t, internal.DeepCopy(reflect.ValueOf(node)).IsNil())
+	assert.Nil(t, internal.DeepCopy(reflect.ValueOf(testCopyNode{})).Interface().(testCopyNode).attrs)
+}
@@ -1,13 +1,37 @@
 package internal
 
-import "reflect"
+import (
+	"reflect"
+	"strconv"
+)
 
+// ServiceName returns the display name of a type in the form "<pkg>.<name>".
+// Unnamed types are described by their composite form, e.g. "*<pkg>.<name>" or "[]<pkg>.<name>".
 func ServiceName(rt reflect.Type) string {
-	if rt == nil || (rt.PkgPath() == "" && rt.Name() == "") {
+	if rt == nil {
 		return ""
 	}
 
-	return rt.PkgPath() + "." + rt.Name()
+	if rt.Name() != "" {
+		if rt.PkgPath() == "" {
+			return rt.Name()
+		}
+
+		return rt.PkgPath() + "." + rt.Name()
+	}
+
+	switch rt.Kind() { //nolint:exhaustive
+	case reflect.Ptr:
+		return "*" + ServiceName(rt.Elem())
+	case reflect.Slice:
+		return "[]" + ServiceName(rt.Elem())
+	case reflect.Array:
+		return "[" + strconv.Itoa(rt.Len()) + "]" + ServiceName(rt.Elem())
+	case reflect.Map:
+		return "map[" + ServiceName(rt.Key()) + "]" + ServiceName(rt.Elem())
+	default:
+		return rt.String()
+	}
 }
 
 func IsStructType(rt reflect.Type) bool {
@@ -14,8 +14,17 @@ func TestServiceName(t *testing.T) {
 
 	name := internal.ServiceName(reflect.TypeOf(testStruct{}))
 	assert.Equal(t, "github.com/goplexhq/needle/internal_test.testStruct", name)
-	assert.Equal(t, "", internal.ServiceName(reflect.TypeOf(struct{}{})))
+	assert.Equal(t, "struct {}", internal.ServiceName(reflect.TypeOf(struct{}{})))
 	assert.Equal(t, "", internal.ServiceName(reflect.TypeOf(nil)))
+	assert.Equal(t, "int", internal.ServiceName(reflect.TypeOf(0)))
+	assert.Equal(t, "error", internal.ServiceName(reflect.TypeFor[error]()))
+	assert.Equal(t, "func(string) error", internal.ServiceName(reflect.TypeFor[func(string) error]()))
+
+	pkg := "github.com/goplexhq/needle/internal_test."
+	assert.Equal(t, "*"+pkg+"testStruct", internal.ServiceName(reflect.TypeOf(&testStruct{})))
+	assert.Equal(t, "[]"+pkg+"testStruct", internal.ServiceName(reflect.TypeOf([]testStruct{})))
+	assert.Equal(t, "[2]"+pkg+"testStruct", internal.ServiceName(reflect.TypeOf([2]testStruct{})))
+	assert.Equal(t, "map[string]"+pkg+"testStruct", internal.ServiceName(reflect.TypeOf(map[string]testStruct{})))
 }
 
 func TestIsStructType(t *testing.T) {
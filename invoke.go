@@ -0,0 +1,115 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+//nolint:gochecknoglobals
+var errorType = reflect.TypeFor[error]()
+
+// Invoke calls a function with arguments resolved from the global registry.
+// Returns the results of the function, or an error if an argument cannot be resolved.
+//
+// The optFuncs parameter allows for optional configuration of the resolution, such as setting a scope or thread ID.
+//
+// Available options:
+// - WithScope(scope string): Sets a scope for resolving scoped dependencies.
+// - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//
+// Every parameter of the function must be a pointer to a registered service. When the last result of the function
+// is an error, it is removed from the returned results and returned as the error instead.
+//
+// Example:
+//
+//	results, err := needle.Invoke(func(cfg *Config, logger *Logger) error {
+//	    ...
+//	})
+//	if err != nil {
+//	    ...
+//	}
+func Invoke(fn any, optFuncs ...ResolutionOptionFunc) ([]any, error) {
+	ensureGlobalRegistryInitialized()
+
+	return InvokeFromRegistry(globalRegistry, fn, optFuncs...)
+}
+
+// InvokeFromRegistry calls a function with arguments resolved from the specified registry.
+// Returns the results of the function, or an error if an argument cannot be resolved.
+//
+// The optFuncs parameter allows for optional configuration of the resolution, such as setting a scope or thread ID.
+//
+// Available options:
+// - WithScope(scope string): Sets a scope for resolving scoped dependencies.
+// - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//
+// Every parameter of the function must be a pointer to a registered service. When the last result of the function
+// is an error, it is removed from the returned results and returned as the error instead.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	results, err := needle.InvokeFromRegistry(registry, func(cfg *Config, logger *Logger) error {
+//	    ...
+//	}, needle.WithScope("request1"))
+//	if err != nil {
+//	    ...
+//	}
+func InvokeFromRegistry(registry *Registry, fn any, optFuncs ...ResolutionOptionFunc) ([]any, error) {
+	fnValue := reflect.ValueOf(fn)
+	if fnValue.Kind() != reflect.Func || fnValue.IsNil() {
+		return nil, fmt.Errorf("%w: %T", ErrInvalidFunc, fn)
+	}
+
+	opt := newResolutionOptions(optFuncs...)
+
+	args, err := resolveArgs(registry, fnValue.Type(), opt.withDependent(fnValue.Type()))
+	if err != nil {
+		return nil, err
+	}
+
+	return splitResults(fnValue.Call(args))
+}
+
+// resolveArgs resolves the arguments of a function type from the registry.
+func resolveArgs(registry *Registry, fnType reflect.Type, opt *ResolutionOptions) ([]reflect.Value, error) {
+	args := make([]reflect.Value, fnType.NumIn())
+
+	for idx := range fnType.NumIn() {
+		paramType := fnType.In(idx)
+		if paramType.Kind() != reflect.Ptr || (fnType.IsVariadic() && idx == fnType.NumIn()-1) {
+			return nil, fmt.Errorf("%w: #%d %s", ErrParamPtr, idx, paramType)
+		}
+
+		arg, err := resolveDependency(registry, paramType.Elem(), opt)
+		if err != nil {
+			return nil, fmt.Errorf("%w #%d %s: %w", ErrResolveParam, idx, internal.ServiceName(paramType.Elem()), err)
+		}
+
+		args[idx] = reflect.ValueOf(arg)
+	}
+
+	return args, nil
+}
+
+// splitResults converts the results of a function call, returning a trailing error result separately.
+func splitResults(results []reflect.Value) ([]any, error) {
+	var err error
+
+	if n := len(results); n > 0 && results[n-1].Type() == errorType {
+		if !results[n-1].IsNil() {
+			err, _ = results[n-1].Interface().(error)
+		}
+
+		results = results[:n-1]
+	}
+
+	values := make([]any, len(results))
+	for idx, result := range results {
+		values[idx] = result.Interface()
+	}
+
+	return values, err
+}
@@ -0,0 +1,72 @@
+package needle_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedle_Invoke(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Config struct{ name string }
+
+	type Logger struct{ prefix string }
+
+	require.NoError(t, needle.RegisterSingletonInstance(&Config{name: "myApp"}))
+	require.NoError(t, needle.RegisterSingletonInstance(&Logger{prefix: "INFO"}))
+
+	results, err := needle.Invoke(func(cfg *Config, logger *Logger) string {
+		return logger.prefix + " " + cfg.name
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []any{"INFO myApp"}, results)
+}
+
+func TestNeedle_InvokeFromRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Dep struct{ name string }
+
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterScopedInstanceToRegistry(registry, &Dep{name: "myDep"}, needle.WithScope("scope")))
+
+	results, err := needle.InvokeFromRegistry(registry, func(dep *Dep) (string, error) {
+		return dep.name, nil
+	}, needle.WithScope("scope"))
+	require.NoError(t, err)
+	assert.Equal(t, []any{"myDep"}, results)
+
+	_, err = needle.InvokeFromRegistry(registry, func(*Dep) {})
+	require.ErrorIs(t, err, needle.ErrResolveParam)
+	require.ErrorIs(t, err, needle.ErrEmptyScope)
+}
+
+func TestNeedle_InvokeReturnsError(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	errExpected := errors.New("expected") //nolint:err113
+
+	results, err := needle.Invoke(func() (int, error) { return 0, errExpected })
+	require.ErrorIs(t, err, errExpected)
+	assert.Equal(t, []any{0}, results)
+}
+
+func TestNeedle_InvokeInvalid(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Dep struct{}
+
+	_, err := needle.Invoke("not a function")
+	require.ErrorIs(t, err, needle.ErrInvalidFunc)
+
+	_, err = needle.Invoke(func(Dep) {})
+	require.ErrorIs(t, err, needle.ErrParamPtr)
+
+	_, err = needle.Invoke(func(*Dep) {})
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+}
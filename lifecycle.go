@@ -0,0 +1,49 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// PostConstructor is implemented by services deriving state from their dependencies or checking their invariants
+// once they are created and their fields are injected. An error fails the resolution or injection.
+type PostConstructor interface {
+	PostConstruct() error
+}
+
+// Validator is implemented by services checking their invariants once they are created and their fields are
+// injected, after PostConstruct. An error fails the resolution or injection.
+type Validator interface {
+	Validate() error
+}
+
+// PreDestroyer is implemented by services releasing state before they are disposed.
+// PreDestroy is called before Shutdown or Close, including for services implementing neither.
+type PreDestroyer interface {
+	PreDestroy()
+}
+
+// initialize calls PostConstruct and then Validate on a created and injected instance, if implemented.
+func initialize(value reflect.Value) error {
+	if !value.IsValid() || !value.CanInterface() {
+		return nil
+	}
+
+	inst := value.Interface()
+
+	if constructor, ok := inst.(PostConstructor); ok {
+		if err := constructor.PostConstruct(); err != nil {
+			return fmt.Errorf("%w %s: %w", ErrPostConstruct, internal.ServiceName(value.Type()), err)
+		}
+	}
+
+	if validator, ok := inst.(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return fmt.Errorf("%w %s: %w", ErrValidate, internal.ServiceName(value.Type()), err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,116 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNeedleLifecycle = errors.New("port must be positive")
+
+type testNeedleLifecycleConfig struct {
+	Port int
+}
+
+type testNeedleLifecycleServer struct {
+	Config    *testNeedleLifecycleConfig `needle:"inject"`
+	Address   string
+	destroyed bool
+	closed    bool
+}
+
+func (s *testNeedleLifecycleServer) PostConstruct() error {
+	s.Address = ":" + strconv.Itoa(s.Config.Port)
+
+	return nil
+}
+
+func (s *testNeedleLifecycleServer) Validate() error {
+	if s.Config.Port <= 0 {
+		return errTestNeedleLifecycle
+	}
+
+	return nil
+}
+
+func (s *testNeedleLifecycleServer) PreDestroy() {
+	s.destroyed = true
+}
+
+func (s *testNeedleLifecycleServer) Close() error {
+	if !s.destroyed {
+		return errTestNeedleLifecycle
+	}
+
+	s.closed = true
+
+	return nil
+}
+
+type testNeedleLifecycleHandler struct {
+	Server    *testNeedleLifecycleServer `needle:"inject"`
+	destroyed bool
+}
+
+func (h *testNeedleLifecycleHandler) PreDestroy() {
+	h.destroyed = true
+}
+
+func TestNeedle_Lifecycle_PostConstruct(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testNeedleLifecycleConfig{Port: 8}))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleLifecycleServer](registry, needle.Singleton))
+
+	server, err := needle.ResolveFromRegistry[testNeedleLifecycleServer](registry)
+	require.NoError(t, err)
+	assert.Equal(t, ":8", server.Address)
+
+	handler := &testNeedleLifecycleHandler{} //nolint:exhaustruct
+
+	require.NoError(t, needle.InjectStructFieldsFromRegistry(registry, handler))
+	assert.Same(t, server, handler.Server)
+}
+
+func TestNeedle_Lifecycle_Validate(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testNeedleLifecycleConfig{Port: 0}))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleLifecycleServer](registry, needle.Singleton))
+
+	_, err := needle.ResolveFromRegistry[testNeedleLifecycleServer](registry)
+	require.ErrorIs(t, err, needle.ErrValidate)
+	require.ErrorIs(t, err, errTestNeedleLifecycle)
+
+	server := &testNeedleLifecycleServer{} //nolint:exhaustruct
+
+	err = needle.InjectStructFieldsFromRegistry(registry, server)
+	require.ErrorIs(t, err, needle.ErrValidate)
+
+	_, err = needle.ResolveFromRegistry[testNeedleLifecycleServer](registry)
+	require.ErrorIs(t, err, needle.ErrValidate, "failed instances are not stored")
+}
+
+func TestNeedle_Lifecycle_PreDestroy(t *testing.T) {
+	registry := needle.NewRegistry()
+	handler := &testNeedleLifecycleHandler{} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testNeedleLifecycleConfig{Port: 8}))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleLifecycleServer](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, handler))
+
+	server, err := needle.ResolveFromRegistry[testNeedleLifecycleServer](registry)
+	require.NoError(t, err)
+
+	report, err := registry.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, report.Services, 2)
+	assert.True(t, handler.destroyed)
+	assert.True(t, server.destroyed)
+	assert.True(t, server.closed)
+}
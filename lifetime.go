@@ -8,6 +8,8 @@ const (
 	Scoped      Lifetime = "SCOPED"       // A single instance of the dependency is created per scope, i.e. web request.
 	ThreadLocal Lifetime = "THREAD_LOCAL" // A single instance of the dependency is created per thread.
 	Singleton   Lifetime = "SINGLETON"    // A single instance is created and shared for the application's entire lifetime.
+	Pooled      Lifetime = "POOLED"       // Instances are borrowed from a pool and returned to it once released.
+	Prototype   Lifetime = "PROTOTYPE"    // A copy of a registered template instance is created each time it is requested.
 )
 
 // Values returns all possible values of Lifetime.
@@ -17,10 +19,13 @@ func (Lifetime) Values() []Lifetime {
 		Scoped,
 		ThreadLocal,
 		Singleton,
+		Pooled,
+		Prototype,
 	}
 }
 
-// Valid checks if the Lifetime value is valid.
+// Valid checks if the Lifetime value is a built-in lifetime. Custom lifetimes are only valid in the registries
+// they are added to with WithLifetime.
 func (lifetime Lifetime) Valid() bool {
 	for _, name := range lifetime.Values() {
 		if lifetime == name {
@@ -0,0 +1,129 @@
+package needle
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+const defaultSlowThreshold = 100 * time.Millisecond
+
+// loggingObserver is an Observer logging the events of a registry with slog.
+type loggingObserver struct {
+	logger        *slog.Logger
+	slowThreshold time.Duration
+}
+
+// OnRegister logs registrations at debug level, and rejected registrations at warn level.
+func (o *loggingObserver) OnRegister(event RegisterEvent) {
+	attrs := []slog.Attr{
+		slog.String("service", event.Name),
+		slog.String("lifetime", event.Lifetime.String()),
+		slog.String("registration", string(event.Registration)),
+	}
+	attrs = appendLocation(attrs, event.Scope, event.ThreadID)
+
+	if event.Err != nil {
+		level := slog.LevelError
+		if errors.Is(event.Err, ErrRegistered) {
+			level = slog.LevelWarn
+		}
+
+		o.log(level, "service registration rejected", append(attrs, slog.Any("error", event.Err))...)
+
+		return
+	}
+
+	o.log(slog.LevelDebug, "service registered", attrs...)
+}
+
+// OnResolveStart implements Observer.
+func (o *loggingObserver) OnResolveStart(ResolveEvent) {}
+
+// OnResolveEnd logs failed resolutions at error level. Failures of dependencies are only logged by the outermost
+// resolution, whose error wraps them.
+func (o *loggingObserver) OnResolveEnd(event ResolveEvent) {
+	if event.Err == nil || len(event.Chain) > 0 {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("service", event.Name),
+		slog.String("lifetime", event.Lifetime.String()),
+		slog.Duration("duration", event.Duration),
+	}
+	attrs = appendLocation(attrs, event.Scope, event.ThreadID)
+
+	o.log(slog.LevelError, "service resolution failed", append(attrs, slog.Any("error", event.Err))...)
+}
+
+// OnCreate logs created instances at debug level, and instances slower to create than the threshold at warn
+// level. Failed creations are logged along with the failed resolution.
+func (o *loggingObserver) OnCreate(event CreateEvent) {
+	if event.Err != nil {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("service", event.Name),
+		slog.String("lifetime", event.Lifetime.String()),
+		slog.Bool("factory", event.Factory),
+		slog.Duration("duration", event.Duration),
+	}
+	attrs = appendLocation(attrs, event.Scope, event.ThreadID)
+
+	if o.slowThreshold > 0 && event.Duration >= o.slowThreshold {
+		o.log(slog.LevelWarn, "slow service creation", append(attrs, slog.Duration("threshold", o.slowThreshold))...)
+
+		return
+	}
+
+	o.log(slog.LevelDebug, "service instance created", attrs...)
+}
+
+// OnScopeCreate logs created scopes at debug level.
+func (o *loggingObserver) OnScopeCreate(event ScopeEvent) {
+	o.log(slog.LevelDebug, "scope created", slog.String("scope", event.Scope))
+}
+
+// OnScopeClose logs closed scopes at debug level.
+func (o *loggingObserver) OnScopeClose(event ScopeEvent) {
+	o.log(slog.LevelDebug, "scope closed", slog.String("scope", event.Scope))
+}
+
+// OnDispose logs disposed instances at debug level, and failed disposals at error level.
+func (o *loggingObserver) OnDispose(event DisposeEvent) {
+	attrs := []slog.Attr{
+		slog.String("service", event.Name),
+		slog.String("lifetime", event.Lifetime.String()),
+		slog.Duration("duration", event.Duration),
+	}
+	attrs = appendLocation(attrs, event.Scope, event.ThreadID)
+
+	if event.Err != nil {
+		o.log(slog.LevelError, "service disposal failed", append(attrs, slog.Any("error", event.Err))...)
+
+		return
+	}
+
+	o.log(slog.LevelDebug, "service instance disposed", attrs...)
+}
+
+// log writes a record with the given level and attributes.
+func (o *loggingObserver) log(level slog.Level, msg string, attrs ...slog.Attr) {
+	o.logger.LogAttrs(context.Background(), level, msg, attrs...)
+}
+
+// appendLocation appends the scope and thread ID of an event to the attributes, when set.
+func appendLocation(attrs []slog.Attr, scope, threadID string) []slog.Attr {
+	if scope != "" {
+		attrs = append(attrs, slog.String("scope", scope))
+	}
+
+	if threadID != "" {
+		attrs = append(attrs, slog.String("thread", threadID))
+	}
+
+	return attrs
+}
@@ -0,0 +1,117 @@
+package needle_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedleLogBuffer struct {
+	mutex  sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *testNeedleLogBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *testNeedleLogBuffer) records(t *testing.T) []map[string]any {
+	t.Helper()
+
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	var records []map[string]any
+
+	for _, line := range bytes.Split(bytes.TrimSpace(b.buffer.Bytes()), []byte("\n")) {
+		var record map[string]any
+
+		require.NoError(t, json.Unmarshal(line, &record))
+
+		records = append(records, record)
+	}
+
+	return records
+}
+
+func newTestNeedleLogger(level slog.Level) (*slog.Logger, *testNeedleLogBuffer) {
+	buffer := &testNeedleLogBuffer{} //nolint:exhaustruct
+
+	return slog.New(slog.NewJSONHandler(buffer, &slog.HandlerOptions{Level: level})), buffer //nolint:exhaustruct
+}
+
+func TestNeedle_Logger(t *testing.T) {
+	logger, buffer := newTestNeedleLogger(slog.LevelDebug)
+	registry := needle.NewRegistry(needle.WithLogger(logger))
+
+	require.NoError(t, needle.RegisterScopedInstanceToRegistry(
+		registry, &testNeedleCloser{name: "closer"}, needle.WithScope("request1"))) //nolint:exhaustruct
+	require.Error(t, needle.RegisterScopedInstanceToRegistry(
+		registry, &testNeedleCloser{name: "closer"}, needle.WithScope("request1"))) //nolint:exhaustruct
+
+	_, err := needle.ResolveFromRegistry[testNeedleShutdownRepo](registry)
+	require.Error(t, err)
+
+	require.NoError(t, needle.UnregisterFromRegistry[testNeedleCloser](registry))
+
+	records := buffer.records(t)
+	messages := make([]string, 0, len(records))
+
+	for _, record := range records {
+		messages = append(messages, record["msg"].(string))
+	}
+
+	assert.Equal(t, []string{
+		"service registered",
+		"scope created",
+		"service registration rejected",
+		"service resolution failed",
+		"service instance disposed",
+		"scope closed",
+	}, messages)
+
+	assert.Equal(t, "DEBUG", records[0]["level"])
+	assert.Equal(t, "github.com/goplexhq/needle_test.testNeedleCloser", records[0]["service"])
+	assert.Equal(t, "SCOPED", records[0]["lifetime"])
+	assert.Equal(t, "request1", records[0]["scope"])
+	assert.Equal(t, "WARN", records[2]["level"])
+	assert.Contains(t, records[2]["error"], needle.ErrRegistered.Error())
+	assert.Equal(t, "ERROR", records[3]["level"])
+	assert.Contains(t, records[3]["error"], needle.ErrNotRegistered.Error())
+}
+
+func TestNeedle_Logger_SlowCreation(t *testing.T) {
+	logger, buffer := newTestNeedleLogger(slog.LevelWarn)
+	registry := needle.NewRegistry(needle.WithLogger(logger), needle.WithSlowThreshold(time.Millisecond))
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleShutdownLog](registry, needle.Singleton,
+		func() *testNeedleShutdownLog {
+			time.Sleep(5 * time.Millisecond)
+
+			return &testNeedleShutdownLog{} //nolint:exhaustruct
+		}))
+
+	for range 2 {
+		_, err := needle.ResolveFromRegistry[testNeedleShutdownLog](registry)
+		require.NoError(t, err)
+	}
+
+	_, err := registry.Shutdown(context.Background())
+	require.NoError(t, err)
+
+	records := buffer.records(t)
+	require.Len(t, records, 1)
+	assert.Equal(t, "slow service creation", records[0]["msg"])
+	assert.Equal(t, "SINGLETON", records[0]["lifetime"])
+}
@@ -10,9 +10,12 @@ var (
 
 // InitGlobalRegistry initializes the global registry if it has not been initialized already.
 // This function is thread-safe and ensures that the registry is only initialized once.
-func InitGlobalRegistry() {
+//
+// The optFuncs parameter configures the global registry, and is ignored once the registry is initialized.
+// Call InitGlobalRegistry before using the global registry to configure it, for instance with an observer.
+func InitGlobalRegistry(optFuncs ...RegistryOptionFunc) {
 	once.Do(func() {
-		globalRegistry = NewRegistry()
+		globalRegistry = NewRegistry(optFuncs...)
 	})
 }
 
@@ -0,0 +1,194 @@
+// Package needledebug provides an HTTP handler exposing the state of a needle registry.
+//
+// The handler renders the registered services, their lifetimes, the active scopes, the thread-local instances held
+// per goroutine and the dependency graph, as HTML or as JSON when requested with "?format=json" or an
+// "Accept: application/json" header. It can be mounted like net/http/pprof:
+//
+//	mux := http.NewServeMux()
+//	needledebug.Register(mux, registry)
+//	// browse http://localhost:8080/debug/needle/
+package needledebug
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goplexhq/needle"
+)
+
+// Path is the path under which Register mounts the handler.
+const Path = "/debug/needle/"
+
+// Snapshot is the state of a registry as rendered by the handler.
+type Snapshot struct {
+	Services []Service           `json:"services"`
+	Scopes   map[string][]string `json:"scopes"`  // Service names holding an instance, keyed by scope.
+	Threads  map[string][]string `json:"threads"` // Service names holding an instance, keyed by thread ID.
+	Graph    []Edge              `json:"graph"`
+}
+
+// Service describes a registered service.
+type Service struct {
+	Name         string     `json:"name"`
+	Lifetime     string     `json:"lifetime"`
+	Registration string     `json:"registration"`
+	Scopes       []string   `json:"scopes,omitempty"`
+	ThreadIDs    []string   `json:"threadIds,omitempty"`
+	Instances    []Instance `json:"instances,omitempty"`
+	Dependencies []string   `json:"dependencies,omitempty"`
+}
+
+// Instance describes an instance of a service held by the registry.
+type Instance struct {
+	Scope     string    `json:"scope,omitempty"`
+	ThreadID  string    `json:"threadId,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Edge is a dependency of one service on another.
+type Edge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+// Register mounts the handler for the registry on the mux under Path.
+func Register(mux *http.ServeMux, registry *needle.Registry) {
+	mux.Handle(Path, Handler(registry))
+}
+
+// Handler returns an http.Handler rendering the state of the registry as HTML or JSON.
+func Handler(registry *needle.Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		snapshot := NewSnapshot(registry)
+
+		if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+
+			encoder := json.NewEncoder(w)
+			encoder.SetIndent("", "  ")
+
+			if err := encoder.Encode(snapshot); err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+			}
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+
+		if err := pageTemplate.Execute(w, snapshot); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// NewSnapshot captures the current state of the registry.
+func NewSnapshot(registry *needle.Registry) Snapshot {
+	snapshot := Snapshot{
+		Services: []Service{},
+		Scopes:   map[string][]string{},
+		Threads:  map[string][]string{},
+		Graph:    []Edge{},
+	}
+
+	for _, info := range registry.Describe() {
+		service := Service{
+			Name:         info.Name,
+			Lifetime:     info.Lifetime.String(),
+			Registration: string(info.Registration),
+			Scopes:       info.Scopes,
+			ThreadIDs:    info.ThreadIDs,
+			Instances:    make([]Instance, 0, len(info.Instances)),
+			Dependencies: info.Dependencies,
+		}
+
+		for _, inst := range info.Instances {
+			service.Instances = append(service.Instances, Instance{
+				Scope:     inst.Scope,
+				ThreadID:  inst.ThreadID,
+				CreatedAt: inst.CreatedAt,
+			})
+		}
+
+		for _, scope := range info.Scopes {
+			snapshot.Scopes[scope] = append(snapshot.Scopes[scope], info.Name)
+		}
+
+		for _, thread := range info.ThreadIDs {
+			snapshot.Threads[thread] = append(snapshot.Threads[thread], info.Name)
+		}
+
+		for _, dep := range info.Dependencies {
+			snapshot.Graph = append(snapshot.Graph, Edge{From: info.Name, To: dep})
+		}
+
+		snapshot.Services = append(snapshot.Services, service)
+	}
+
+	for _, names := range snapshot.Scopes {
+		slices.Sort(names)
+	}
+
+	for _, names := range snapshot.Threads {
+		slices.Sort(names)
+	}
+
+	return snapshot
+}
+
+//nolint:gochecknoglobals
+var pageTemplate = template.Must(template.New("needle").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<title>needle registry</title>
+<style>
+body { font-family: sans-serif; font-size: 14px; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; vertical-align: top; }
+th { background: #eee; }
+</style>
+</head>
+<body>
+<h1>needle registry</h1>
+<p><a href="?format=json">json</a></p>
+
+<h2>Services ({{len .Services}})</h2>
+<table>
+<tr><th>Name</th><th>Lifetime</th><th>Registration</th><th>Instances</th><th>Dependencies</th></tr>
+{{range .Services}}<tr>
+<td>{{.Name}}</td>
+<td>{{.Lifetime}}</td>
+<td>{{.Registration}}</td>
+<td>{{range .Instances}}
+	{{- if .Scope}}scope {{.Scope}} {{end}}
+	{{- if .ThreadID}}thread {{.ThreadID}} {{end -}}
+	created {{.CreatedAt.Format "2006-01-02T15:04:05.000Z07:00"}}<br>
+{{- end}}</td>
+<td>{{range .Dependencies}}{{.}}<br>{{end}}</td>
+</tr>
+{{end}}</table>
+
+<h2>Scopes ({{len .Scopes}})</h2>
+<table>
+<tr><th>Scope</th><th>Services</th></tr>
+{{range $scope, $names := .Scopes}}<tr><td>{{$scope}}</td><td>{{range $names}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+
+<h2>Threads ({{len .Threads}})</h2>
+<table>
+<tr><th>Thread</th><th>Services</th></tr>
+{{range $thread, $names := .Threads}}<tr><td>{{$thread}}</td><td>{{range $names}}{{.}}<br>{{end}}</td></tr>
+{{end}}</table>
+
+<h2>Dependency graph ({{len .Graph}})</h2>
+<table>
+<tr><th>Service</th><th>Depends on</th></tr>
+{{range .Graph}}<tr><td>{{.From}}</td><td>{{.To}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
@@ -0,0 +1,71 @@
+package needledebug_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/goplexhq/needle/needledebug"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Logger struct{}
+
+type Service struct {
+	Logger *Logger `needle:"inject"`
+}
+
+func newTestRegistry(t *testing.T) *needle.Registry {
+	t.Helper()
+
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &Logger{}))
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.Scoped, needle.WithScope("request1")))
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.ThreadLocal, needle.WithThreadID("7")))
+
+	_, err := needle.ResolveFromRegistry[Service](registry, needle.WithThreadID("7"))
+	require.NoError(t, err)
+
+	return registry
+}
+
+func TestHandler_JSON(t *testing.T) {
+	mux := http.NewServeMux()
+	needledebug.Register(mux, newTestRegistry(t))
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, needledebug.Path+"?format=json", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var snapshot needledebug.Snapshot
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &snapshot))
+	require.Len(t, snapshot.Services, 2)
+
+	logger := "github.com/goplexhq/needle/needledebug_test.Logger"
+	service := "github.com/goplexhq/needle/needledebug_test.Service"
+
+	assert.Equal(t, logger, snapshot.Services[0].Name)
+	assert.Equal(t, "SINGLETON", snapshot.Services[0].Lifetime)
+	assert.Equal(t, "INSTANCE", snapshot.Services[0].Registration)
+	assert.Equal(t, "THREAD_LOCAL", snapshot.Services[1].Lifetime)
+	assert.Equal(t, map[string][]string{"7": {service}}, snapshot.Threads)
+	assert.Empty(t, snapshot.Scopes)
+	assert.Equal(t, []needledebug.Edge{{From: service, To: logger}}, snapshot.Graph)
+}
+
+func TestHandler_HTML(t *testing.T) {
+	rec := httptest.NewRecorder()
+	needledebug.Handler(newTestRegistry(t)).ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/html; charset=utf-8", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "github.com/goplexhq/needle/needledebug_test.Service")
+	assert.Contains(t, rec.Body.String(), "thread 7")
+}
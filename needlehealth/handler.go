@@ -0,0 +1,117 @@
+// Package needlehealth provides HTTP handlers for liveness and readiness probes backed by a needle registry.
+//
+// The readiness handler checks every service of the registry implementing needle.HealthChecker and responds with
+// 200 when all of them are healthy, or 503 otherwise, along with a JSON report. The liveness handler only reports
+// that the process is serving requests and never checks dependencies, so that an unavailable dependency does not
+// restart the process:
+//
+//	mux := http.NewServeMux()
+//	needlehealth.Register(mux, registry)
+//	// probe http://localhost:8080/livez and http://localhost:8080/readyz
+package needlehealth
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/goplexhq/needle"
+)
+
+// Paths under which Register mounts the handlers.
+const (
+	LivenessPath  = "/livez"
+	ReadinessPath = "/readyz"
+)
+
+// Statuses reported by the handlers.
+const (
+	StatusOK          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+// Report is the health of a registry as rendered by the readiness handler.
+type Report struct {
+	Status   string  `json:"status"`
+	Services []Check `json:"services"`
+}
+
+// Check is the health of a service instance.
+type Check struct {
+	Name     string `json:"name"`
+	Lifetime string `json:"lifetime"`
+	Scope    string `json:"scope,omitempty"`
+	ThreadID string `json:"threadId,omitempty"`
+	Status   string `json:"status"`
+	Duration string `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+// Register mounts the liveness handler under LivenessPath and the readiness handler for the registry under
+// ReadinessPath.
+func Register(mux *http.ServeMux, registry *needle.Registry, optFuncs ...needle.HealthOptionFunc) {
+	mux.Handle(LivenessPath, LivenessHandler())
+	mux.Handle(ReadinessPath, ReadinessHandler(registry, optFuncs...))
+}
+
+// LivenessHandler returns an http.Handler responding with 200 as long as the process serves requests.
+func LivenessHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeJSON(w, http.StatusOK, Report{Status: StatusOK, Services: []Check{}})
+	})
+}
+
+// ReadinessHandler returns an http.Handler checking the health of the registry, responding with 200 when every
+// service is healthy and 503 otherwise. Checks are bound by the context of the request.
+func ReadinessHandler(registry *needle.Registry, optFuncs ...needle.HealthOptionFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		health, _ := registry.Health(r.Context(), optFuncs...)
+		report := NewReport(health)
+
+		code := http.StatusOK
+		if !health.Healthy {
+			code = http.StatusServiceUnavailable
+		}
+
+		writeJSON(w, code, report)
+	})
+}
+
+// NewReport converts a health report of a registry into its rendered form.
+func NewReport(health needle.HealthReport) Report {
+	report := Report{Status: StatusOK, Services: make([]Check, 0, len(health.Services))}
+	if !health.Healthy {
+		report.Status = StatusUnavailable
+	}
+
+	for _, service := range health.Services {
+		check := Check{
+			Name:     service.Name,
+			Lifetime: service.Lifetime.String(),
+			Scope:    service.Scope,
+			ThreadID: service.ThreadID,
+			Status:   StatusOK,
+			Duration: service.Duration.String(),
+			Error:    "",
+		}
+
+		if service.Err != nil {
+			check.Status = StatusUnavailable
+			check.Error = service.Err.Error()
+		}
+
+		report.Services = append(report.Services, check)
+	}
+
+	return report
+}
+
+// writeJSON writes a report as JSON with the given status code.
+func writeJSON(w http.ResponseWriter, code int, report Report) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	_ = encoder.Encode(report)
+}
@@ -0,0 +1,77 @@
+package needlehealth_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/goplexhq/needle/needlehealth"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errDown = errors.New("database is down")
+
+type Database struct{ err error }
+
+func (d *Database) CheckHealth(context.Context) error {
+	return d.err
+}
+
+func serve(t *testing.T, registry *needle.Registry, path string) (int, needlehealth.Report) {
+	t.Helper()
+
+	mux := http.NewServeMux()
+	needlehealth.Register(mux, registry)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var report needlehealth.Report
+
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &report))
+
+	return rec.Code, report
+}
+
+func TestReadinessHandler(t *testing.T) {
+	registry := needle.NewRegistry()
+	database := &Database{err: nil}
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, database))
+
+	code, report := serve(t, registry, needlehealth.ReadinessPath)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, needlehealth.StatusOK, report.Status)
+	require.Len(t, report.Services, 1)
+	assert.Equal(t, "github.com/goplexhq/needle/needlehealth_test.Database", report.Services[0].Name)
+	assert.Equal(t, "SINGLETON", report.Services[0].Lifetime)
+	assert.Equal(t, needlehealth.StatusOK, report.Services[0].Status)
+
+	database.err = errDown
+
+	code, report = serve(t, registry, needlehealth.ReadinessPath)
+
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, needlehealth.StatusUnavailable, report.Status)
+	assert.Equal(t, needlehealth.StatusUnavailable, report.Services[0].Status)
+	assert.Contains(t, report.Services[0].Error, errDown.Error())
+}
+
+func TestLivenessHandler(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &Database{err: errDown}))
+
+	code, report := serve(t, registry, needlehealth.LivenessPath)
+
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, needlehealth.StatusOK, report.Status)
+}
@@ -0,0 +1,296 @@
+// Package needlemetrics collects per-service metrics of a needle registry and exposes them in the Prometheus text
+// exposition format, without depending on the Prometheus client library.
+//
+// Metrics observe the registry they are attached to, and are served by a handler mounted on a mux:
+//
+//	metrics := needlemetrics.New()
+//	registry := needle.NewRegistry(needle.WithObserver(metrics))
+//
+//	mux := http.NewServeMux()
+//	needlemetrics.Register(mux, registry, metrics)
+//	// scrape http://localhost:8080/metrics
+package needlemetrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goplexhq/needle"
+)
+
+// Path is the path under which Register mounts the handler.
+const Path = "/metrics"
+
+// contentType is the content type of the Prometheus text exposition format.
+const contentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// DefaultBuckets are the upper bounds, in seconds, of the latency histograms.
+//
+//nolint:gochecknoglobals
+var DefaultBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+
+// Options holds configuration options for collecting metrics.
+type Options struct {
+	buckets []float64
+}
+
+// OptionFunc is a function that modifies an Options struct.
+type OptionFunc func(*Options)
+
+// WithBuckets sets the upper bounds, in seconds, of the latency histograms. Defaults to DefaultBuckets.
+//
+// Example:
+//
+//	metrics := needlemetrics.New(needlemetrics.WithBuckets(0.001, 0.01, 0.1, 1))
+func WithBuckets(buckets ...float64) OptionFunc {
+	return func(o *Options) {
+		o.buckets = slices.Clone(buckets)
+	}
+}
+
+// Metrics collects the resolutions, instance creations, failures and latencies of the services of a registry.
+// It implements needle.Observer and is safe for concurrent use.
+type Metrics struct {
+	needle.NopObserver
+
+	buckets  []float64
+	services map[key]*serviceMetrics
+	lock     sync.Mutex
+}
+
+// key identifies the metrics of a service.
+type key struct {
+	service  string
+	lifetime needle.Lifetime
+}
+
+// serviceMetrics holds the metrics of a service.
+type serviceMetrics struct {
+	resolutions        uint64
+	resolutionFailures uint64
+	creations          uint64
+	creationFailures   uint64
+	resolutionLatency  histogram
+	creationLatency    histogram
+}
+
+// histogram counts observations per bucket, the last bucket counting observations above every upper bound.
+type histogram struct {
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// New creates and returns new Metrics, to attach to a registry with needle.WithObserver.
+func New(optFuncs ...OptionFunc) *Metrics {
+	opt := &Options{buckets: DefaultBuckets}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	buckets := slices.Clone(opt.buckets)
+	slices.Sort(buckets)
+
+	return &Metrics{ //nolint:exhaustruct
+		buckets:  buckets,
+		services: make(map[key]*serviceMetrics),
+	}
+}
+
+// OnResolveEnd counts a resolution and records its latency.
+func (m *Metrics) OnResolveEnd(event needle.ResolveEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	service := m.service(event.Name, event.Lifetime)
+	service.resolutions++
+	service.resolutionLatency.observe(m.buckets, event.Duration)
+
+	if event.Err != nil {
+		service.resolutionFailures++
+	}
+}
+
+// OnCreate counts an instance creation and records the latency of its factory.
+func (m *Metrics) OnCreate(event needle.CreateEvent) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	service := m.service(event.Name, event.Lifetime)
+	service.creationLatency.observe(m.buckets, event.Duration)
+
+	if event.Err != nil {
+		service.creationFailures++
+
+		return
+	}
+
+	service.creations++
+}
+
+// service returns the metrics of a service, creating them if needed. The caller must hold the lock.
+func (m *Metrics) service(name string, lifetime needle.Lifetime) *serviceMetrics {
+	k := key{service: name, lifetime: lifetime}
+
+	service, found := m.services[k]
+	if !found {
+		service = &serviceMetrics{} //nolint:exhaustruct
+		service.resolutionLatency.counts = make([]uint64, len(m.buckets)+1)
+		service.creationLatency.counts = make([]uint64, len(m.buckets)+1)
+		m.services[k] = service
+	}
+
+	return service
+}
+
+// observe records an observation in the histogram.
+func (h *histogram) observe(buckets []float64, duration time.Duration) {
+	seconds := duration.Seconds()
+	idx, _ := slices.BinarySearch(buckets, seconds)
+
+	h.counts[idx]++
+	h.sum += seconds
+	h.count++
+}
+
+// Register mounts the handler for the metrics and the registry on the mux under Path.
+func Register(mux *http.ServeMux, registry *needle.Registry, metrics *Metrics) {
+	mux.Handle(Path, Handler(registry, metrics))
+}
+
+// Handler returns an http.Handler writing the metrics and the live instances of the registry in the Prometheus
+// text exposition format.
+func Handler(registry *needle.Registry, metrics *Metrics) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+
+		if err := metrics.Write(w, registry); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+		}
+	})
+}
+
+// Write writes the metrics, and the number of live instances held by the registry when it is not nil, in the
+// Prometheus text exposition format.
+func (m *Metrics) Write(w io.Writer, registry *needle.Registry) error {
+	var builder strings.Builder
+
+	m.lock.Lock()
+
+	keys := make([]key, 0, len(m.services))
+	for k := range m.services {
+		keys = append(keys, k)
+	}
+
+	slices.SortFunc(keys, func(a, b key) int {
+		if a.service != b.service {
+			return strings.Compare(a.service, b.service)
+		}
+
+		return strings.Compare(string(a.lifetime), string(b.lifetime))
+	})
+
+	counters := []struct {
+		name, help string
+		value      func(*serviceMetrics) uint64
+	}{
+		{"needle_resolutions_total", "Number of service resolutions.",
+			func(s *serviceMetrics) uint64 { return s.resolutions }},
+		{"needle_resolution_failures_total", "Number of failed service resolutions.",
+			func(s *serviceMetrics) uint64 { return s.resolutionFailures }},
+		{"needle_instance_creations_total", "Number of service instances created.",
+			func(s *serviceMetrics) uint64 { return s.creations }},
+		{"needle_instance_creation_failures_total", "Number of service instances that failed to be created.",
+			func(s *serviceMetrics) uint64 { return s.creationFailures }},
+	}
+
+	for _, counter := range counters {
+		writeHeader(&builder, counter.name, counter.help, "counter")
+
+		for _, k := range keys {
+			fmt.Fprintf(&builder, "%s%s %d\n", counter.name, labels(k), counter.value(m.services[k]))
+		}
+	}
+
+	histograms := []struct {
+		name, help string
+		value      func(*serviceMetrics) *histogram
+	}{
+		{"needle_resolution_duration_seconds", "Latency of service resolutions, including dependencies.",
+			func(s *serviceMetrics) *histogram { return &s.resolutionLatency }},
+		{"needle_instance_creation_duration_seconds", "Latency of service factories and lifecycle hooks.",
+			func(s *serviceMetrics) *histogram { return &s.creationLatency }},
+	}
+
+	for _, hist := range histograms {
+		writeHeader(&builder, hist.name, hist.help, "histogram")
+
+		for _, k := range keys {
+			m.writeHistogram(&builder, hist.name, k, hist.value(m.services[k]))
+		}
+	}
+
+	m.lock.Unlock()
+
+	if registry != nil {
+		writeLiveInstances(&builder, registry)
+	}
+
+	_, err := io.WriteString(w, builder.String())
+
+	return err //nolint:wrapcheck
+}
+
+// writeHistogram writes the cumulative buckets, sum and count of a histogram.
+func (m *Metrics) writeHistogram(builder *strings.Builder, name string, k key, hist *histogram) {
+	var cumulative uint64
+
+	for idx, bound := range m.buckets {
+		cumulative += hist.counts[idx]
+		le := strconv.FormatFloat(bound, 'g', -1, 64)
+		fmt.Fprintf(builder, "%s_bucket%s %d\n", name, labels(k, "le", le), cumulative)
+	}
+
+	fmt.Fprintf(builder, "%s_bucket%s %d\n", name, labels(k, "le", "+Inf"), hist.count)
+	fmt.Fprintf(builder, "%s_sum%s %s\n", name, labels(k), strconv.FormatFloat(hist.sum, 'g', -1, 64))
+	fmt.Fprintf(builder, "%s_count%s %d\n", name, labels(k), hist.count)
+}
+
+// writeLiveInstances writes the number of instances held by the registry per service.
+func writeLiveInstances(builder *strings.Builder, registry *needle.Registry) {
+	name := "needle_live_instances"
+	writeHeader(builder, name, "Number of service instances held by the registry.", "gauge")
+
+	for _, info := range registry.Describe() {
+		k := key{service: info.Name, lifetime: info.Lifetime}
+		fmt.Fprintf(builder, "%s%s %d\n", name, labels(k), len(info.Instances))
+	}
+}
+
+// writeHeader writes the HELP and TYPE lines of a metric.
+func writeHeader(builder *strings.Builder, name, help, typ string) {
+	fmt.Fprintf(builder, "# HELP %s %s\n# TYPE %s %s\n", name, help, name, typ)
+}
+
+// labels formats the labels of a service, followed by the extra label name and value pairs.
+func labels(k key, extra ...string) string {
+	pairs := append([]string{"service", k.service, "lifetime", k.lifetime.String()}, extra...)
+	formatted := make([]string, 0, len(pairs)/2) //nolint:mnd
+
+	for idx := 0; idx+1 < len(pairs); idx += 2 {
+		formatted = append(formatted, pairs[idx]+`="`+escape(pairs[idx+1])+`"`)
+	}
+
+	return "{" + strings.Join(formatted, ",") + "}"
+}
+
+// escape escapes a label value as required by the Prometheus text exposition format.
+func escape(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,73 @@
+package needlemetrics_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/goplexhq/needle/needlemetrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type Logger struct{}
+
+type Handler struct {
+	Logger *Logger `needle:"inject"`
+}
+
+func TestHandler(t *testing.T) {
+	metrics := needlemetrics.New(needlemetrics.WithBuckets(1, 0.01))
+	registry := needle.NewRegistry(needle.WithObserver(metrics))
+
+	require.NoError(t, needle.RegisterToRegistry[Logger](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterFactoryToRegistry[Handler](registry, needle.Transient,
+		func(logger *Logger) *Handler {
+			time.Sleep(20 * time.Millisecond)
+
+			return &Handler{Logger: logger}
+		}))
+
+	for range 3 {
+		_, err := needle.ResolveFromRegistry[Handler](registry)
+		require.NoError(t, err)
+	}
+
+	_, err := needle.ResolveFromRegistry[needle.Registry](registry)
+	require.Error(t, err)
+
+	mux := http.NewServeMux()
+	needlemetrics.Register(mux, registry, metrics)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, needlemetrics.Path, nil))
+
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "text/plain; version=0.0.4; charset=utf-8", rec.Header().Get("Content-Type"))
+
+	handler := `service="github.com/goplexhq/needle/needlemetrics_test.Handler",lifetime="TRANSIENT"`
+	logger := `service="github.com/goplexhq/needle/needlemetrics_test.Logger",lifetime="SINGLETON"`
+	lines := strings.Split(rec.Body.String(), "\n")
+
+	for _, line := range []string{
+		"# TYPE needle_resolutions_total counter",
+		"needle_resolutions_total{" + handler + "} 3",
+		"needle_resolutions_total{" + logger + "} 3",
+		`needle_resolution_failures_total{service="github.com/goplexhq/needle.Registry",lifetime=""} 1`,
+		"needle_instance_creations_total{" + handler + "} 3",
+		"needle_instance_creations_total{" + logger + "} 1",
+		"# TYPE needle_instance_creation_duration_seconds histogram",
+		"needle_instance_creation_duration_seconds_bucket{" + handler + `,le="0.01"} 0`,
+		"needle_instance_creation_duration_seconds_bucket{" + handler + `,le="1"} 3`,
+		"needle_instance_creation_duration_seconds_bucket{" + handler + `,le="+Inf"} 3`,
+		"needle_instance_creation_duration_seconds_count{" + handler + "} 3",
+		"# TYPE needle_live_instances gauge",
+		"needle_live_instances{" + handler + "} 0",
+		"needle_live_instances{" + logger + "} 1",
+	} {
+		assert.Contains(t, lines, line)
+	}
+}
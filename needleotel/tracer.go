@@ -0,0 +1,76 @@
+// Package needleotel adapts OpenTelemetry-compatible tracing APIs to needle.Tracer, without depending on the
+// OpenTelemetry modules.
+//
+// The adapter only needs a function starting a span in a context, so that an OpenTelemetry tracer is wired with a
+// few lines in the application:
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) SetAttribute(key, value string) { s.Span.SetAttributes(attribute.String(key, value)) }
+//	func (s otelSpan) RecordError(err error) { s.Span.RecordError(err); s.Span.SetStatus(codes.Error, err.Error()) }
+//
+//	tracer := needleotel.NewTracer(func(ctx context.Context, name string) (context.Context, needleotel.Span) {
+//	    ctx, span := otel.Tracer("needle").Start(ctx, name)
+//	    return ctx, otelSpan{span}
+//	})
+//	registry := needle.NewRegistry(needle.WithTracer(tracer))
+package needleotel
+
+import (
+	"context"
+
+	"github.com/goplexhq/needle"
+)
+
+// Span is the subset of an OpenTelemetry span used by the adapter.
+type Span interface {
+	SetAttribute(key, value string)
+	RecordError(err error)
+	End()
+}
+
+// StartFunc starts a span named name as a child of the span carried by the context, and returns the context
+// carrying the new span, like the Start method of an OpenTelemetry tracer.
+type StartFunc func(ctx context.Context, name string) (context.Context, Span)
+
+// tracer is a needle.Tracer starting spans with a StartFunc.
+type tracer struct {
+	start StartFunc
+}
+
+// NewTracer returns a needle.Tracer starting spans with the given function. Attributes are set on the started
+// span, and errors are recorded on the span before it ends.
+func NewTracer(start StartFunc) needle.Tracer {
+	return &tracer{start: start}
+}
+
+// Start implements needle.Tracer.
+func (t *tracer) Start(ctx context.Context, name string, attrs ...needle.Attribute) (context.Context, needle.Span) {
+	ctx, span := t.start(ctx, name)
+
+	adapted := &adaptedSpan{span: span}
+	adapted.SetAttributes(attrs...)
+
+	return ctx, adapted
+}
+
+// adaptedSpan is the needle.Span of a Span.
+type adaptedSpan struct {
+	span Span
+}
+
+// SetAttributes implements needle.Span.
+func (s *adaptedSpan) SetAttributes(attrs ...needle.Attribute) {
+	for _, attr := range attrs {
+		s.span.SetAttribute(attr.Key, attr.Value)
+	}
+}
+
+// End implements needle.Span.
+func (s *adaptedSpan) End(err error) {
+	if err != nil {
+		s.span.RecordError(err)
+	}
+
+	s.span.End()
+}
@@ -0,0 +1,71 @@
+package needleotel_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/goplexhq/needle/needleotel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errFactory = errors.New("connection refused")
+
+type span struct {
+	name       string
+	parent     *span
+	attributes map[string]string
+	err        error
+	ended      bool
+}
+
+func (s *span) SetAttribute(key, value string) { s.attributes[key] = value }
+
+func (s *span) RecordError(err error) { s.err = err }
+
+func (s *span) End() { s.ended = true }
+
+type spanKey struct{}
+
+type Database struct{}
+
+type Repo struct {
+	Database *Database `needle:"inject"`
+}
+
+func TestNewTracer(t *testing.T) {
+	var spans []*span
+
+	tracer := needleotel.NewTracer(func(ctx context.Context, name string) (context.Context, needleotel.Span) {
+		parent, _ := ctx.Value(spanKey{}).(*span)
+		started := &span{name: name, parent: parent, attributes: map[string]string{}, err: nil, ended: false}
+		spans = append(spans, started)
+
+		return context.WithValue(ctx, spanKey{}, started), started
+	})
+
+	registry := needle.NewRegistry(needle.WithTracer(tracer))
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[Database](registry, needle.Transient,
+		func() (*Database, error) { return nil, errFactory }))
+	require.NoError(t, needle.RegisterToRegistry[Repo](registry, needle.Transient))
+
+	_, err := needle.ResolveFromRegistry[Repo](registry)
+	require.ErrorIs(t, err, errFactory)
+	require.Len(t, spans, 3)
+
+	repo, database, factory := spans[0], spans[1], spans[2]
+
+	assert.Nil(t, repo.parent)
+	assert.Same(t, repo, database.parent)
+	assert.Same(t, database, factory.parent)
+	assert.Equal(t, "github.com/goplexhq/needle/needleotel_test.Database", factory.attributes[needle.AttributeService])
+	assert.Equal(t, "TRANSIENT", factory.attributes[needle.AttributeLifetime])
+
+	for _, s := range spans {
+		assert.True(t, s.ended, s.name)
+		require.ErrorIs(t, s.err, errFactory, s.name)
+	}
+}
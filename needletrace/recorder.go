@@ -0,0 +1,139 @@
+// Package needletrace provides an in-memory needle.Tracer recording the spans of a registry, to assert on the
+// resolutions and factory calls of a registry in tests:
+//
+//	recorder := needletrace.NewRecorder()
+//	registry := needle.NewRegistry(needle.WithTracer(recorder))
+//	...
+//	for _, span := range recorder.Spans() {
+//	    t.Log(span.Name, span.ParentID, span.Duration())
+//	}
+package needletrace
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/goplexhq/needle"
+)
+
+// RecordedSpan is a span recorded by a Recorder.
+type RecordedSpan struct {
+	ID         int               // Identifier of the span, starting at 1 in start order.
+	ParentID   int               // Identifier of the parent span, 0 for a root span.
+	Name       string            // Name of the span.
+	Attributes map[string]string // Attributes of the span.
+	StartTime  time.Time         // Time the span started.
+	EndTime    time.Time         // Time the span ended, zero if it has not ended.
+	Err        error             // Error the span ended with.
+}
+
+// Ended reports whether the span has ended.
+func (s RecordedSpan) Ended() bool {
+	return !s.EndTime.IsZero()
+}
+
+// Duration returns the duration of the span, zero if it has not ended.
+func (s RecordedSpan) Duration() time.Duration {
+	if !s.Ended() {
+		return 0
+	}
+
+	return s.EndTime.Sub(s.StartTime)
+}
+
+// Recorder is a needle.Tracer recording spans in memory. It is safe for concurrent use.
+type Recorder struct {
+	spans []*RecordedSpan
+	lock  sync.Mutex
+}
+
+// spanKey is the context key of the span in which child spans are started.
+type spanKey struct{}
+
+// NewRecorder creates and returns a new Recorder.
+func NewRecorder() *Recorder {
+	return &Recorder{spans: nil} //nolint:exhaustruct
+}
+
+// Start implements needle.Tracer, starting a span as a child of the span carried by the context, if any.
+func (r *Recorder) Start(ctx context.Context, name string, attrs ...needle.Attribute) (context.Context, needle.Span) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	span := &RecordedSpan{
+		ID:         len(r.spans) + 1,
+		ParentID:   0,
+		Name:       name,
+		Attributes: make(map[string]string, len(attrs)),
+		StartTime:  time.Now(),
+		EndTime:    time.Time{},
+		Err:        nil,
+	}
+
+	if parent, ok := ctx.Value(spanKey{}).(*RecordedSpan); ok {
+		span.ParentID = parent.ID
+	}
+
+	for _, attr := range attrs {
+		span.Attributes[attr.Key] = attr.Value
+	}
+
+	r.spans = append(r.spans, span)
+
+	return context.WithValue(ctx, spanKey{}, span), &recorderSpan{recorder: r, span: span}
+}
+
+// Spans returns a copy of the recorded spans, in start order.
+func (r *Recorder) Spans() []RecordedSpan {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	spans := make([]RecordedSpan, 0, len(r.spans))
+
+	for _, span := range r.spans {
+		copied := *span
+		copied.Attributes = make(map[string]string, len(span.Attributes))
+
+		for key, value := range span.Attributes {
+			copied.Attributes[key] = value
+		}
+
+		spans = append(spans, copied)
+	}
+
+	return spans
+}
+
+// Reset discards the recorded spans.
+func (r *Recorder) Reset() {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.spans = nil
+}
+
+// recorderSpan is the needle.Span of a RecordedSpan.
+type recorderSpan struct {
+	recorder *Recorder
+	span     *RecordedSpan
+}
+
+// SetAttributes implements needle.Span.
+func (s *recorderSpan) SetAttributes(attrs ...needle.Attribute) {
+	s.recorder.lock.Lock()
+	defer s.recorder.lock.Unlock()
+
+	for _, attr := range attrs {
+		s.span.Attributes[attr.Key] = attr.Value
+	}
+}
+
+// End implements needle.Span.
+func (s *recorderSpan) End(err error) {
+	s.recorder.lock.Lock()
+	defer s.recorder.lock.Unlock()
+
+	s.span.EndTime = time.Now()
+	s.span.Err = err
+}
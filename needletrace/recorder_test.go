@@ -0,0 +1,42 @@
+package needletrace_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/goplexhq/needle/needletrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errSpan = errors.New("span failed")
+
+func TestRecorder(t *testing.T) {
+	recorder := needletrace.NewRecorder()
+
+	ctx, root := recorder.Start(context.Background(), "root", needle.Attribute{Key: "key", Value: "value"})
+	_, child := recorder.Start(ctx, "child")
+
+	child.SetAttributes(needle.Attribute{Key: "done", Value: "true"})
+	child.End(errSpan)
+
+	spans := recorder.Spans()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "root", spans[0].Name)
+	assert.Zero(t, spans[0].ParentID)
+	assert.Equal(t, map[string]string{"key": "value"}, spans[0].Attributes)
+	assert.False(t, spans[0].Ended())
+	assert.Zero(t, spans[0].Duration())
+
+	assert.Equal(t, spans[0].ID, spans[1].ParentID)
+	assert.Equal(t, map[string]string{"done": "true"}, spans[1].Attributes)
+	assert.True(t, spans[1].Ended())
+	require.ErrorIs(t, spans[1].Err, errSpan)
+
+	root.End(nil)
+	recorder.Reset()
+	assert.Empty(t, recorder.Spans())
+}
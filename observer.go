@@ -0,0 +1,231 @@
+package needle
+
+import (
+	"reflect"
+	"slices"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Observer receives the events of a registry, such as registrations, resolutions, scope lifecycles and
+// disposals, to plug in logging, metrics or tracing. Observers are called synchronously, outside of the registry
+// lock, and must be safe for concurrent use. Embed NopObserver to handle only some of the events.
+type Observer interface {
+	OnRegister(event RegisterEvent)
+	OnResolveStart(event ResolveEvent)
+	OnResolveEnd(event ResolveEvent)
+	OnCreate(event CreateEvent)
+	OnScopeCreate(event ScopeEvent)
+	OnScopeClose(event ScopeEvent)
+	OnDispose(event DisposeEvent)
+}
+
+// NopObserver is an Observer ignoring every event. Embed it to implement only some of the Observer methods.
+type NopObserver struct{}
+
+// OnRegister implements Observer.
+func (NopObserver) OnRegister(RegisterEvent) {}
+
+// OnResolveStart implements Observer.
+func (NopObserver) OnResolveStart(ResolveEvent) {}
+
+// OnResolveEnd implements Observer.
+func (NopObserver) OnResolveEnd(ResolveEvent) {}
+
+// OnCreate implements Observer.
+func (NopObserver) OnCreate(CreateEvent) {}
+
+// OnScopeCreate implements Observer.
+func (NopObserver) OnScopeCreate(ScopeEvent) {}
+
+// OnScopeClose implements Observer.
+func (NopObserver) OnScopeClose(ScopeEvent) {}
+
+// OnDispose implements Observer.
+func (NopObserver) OnDispose(DisposeEvent) {}
+
+// RegisterEvent describes a registration, or a registration rejected because the service is already registered.
+type RegisterEvent struct {
+	Name         string       // Display name of the service.
+	Type         reflect.Type // Type of the service.
+	Lifetime     Lifetime     // Lifetime of the service.
+	Registration Registration // How the service is registered.
+	Scope        string       // Scope of the registration, empty unless the service is Scoped.
+	ThreadID     string       // Thread ID of the registration, empty unless the service is ThreadLocal.
+	Err          error        // Error rejecting the registration, nil on success.
+}
+
+// ResolveEvent describes the resolution of a service. Resolutions of dependencies are reported as nested
+// resolutions, with the services depending on them in Chain.
+type ResolveEvent struct {
+	Name     string         // Display name of the service.
+	Type     reflect.Type   // Type of the service.
+	Lifetime Lifetime       // Lifetime of the service, empty if the service is not registered.
+	Scope    string         // Scope of the resolution.
+	ThreadID string         // Thread ID of the resolution, empty unless the service is ThreadLocal.
+	Chain    []reflect.Type // Services depending on the service, outermost first.
+	Duration time.Duration  // Time spent resolving the service, zero when the resolution starts.
+	CacheHit bool           // Whether an instance held by the registry was returned instead of a new instance.
+	Err      error          // Error that occurred while resolving the service, nil on success.
+}
+
+// CreateEvent describes the creation of a service instance by the registry, once its dependencies are resolved.
+// Failures to resolve the dependencies are reported by the resolution events only.
+type CreateEvent struct {
+	Name     string         // Display name of the service.
+	Type     reflect.Type   // Type of the service.
+	Lifetime Lifetime       // Lifetime of the service.
+	Scope    string         // Scope of the resolution.
+	ThreadID string         // Thread ID of the resolution, empty unless the service is ThreadLocal.
+	Chain    []reflect.Type // Services depending on the service, outermost first, ending with the service.
+	Factory  bool           // Whether the instance was created by a factory rather than allocated and injected.
+	Duration time.Duration  // Time spent in the factory and lifecycle hooks, excluding dependency resolution.
+	Err      error          // Error returned by the factory or a lifecycle hook, nil on success.
+}
+
+// ScopeEvent describes the creation or closing of a scope. A scope is created when it is opened or its first
+// instance is registered, and closed when it is closed, when its last instance is removed unless it was opened, or
+// when the registry is cleared.
+type ScopeEvent struct {
+	Scope string // Name of the scope.
+}
+
+// DisposeEvent describes the disposal of a service instance.
+type DisposeEvent struct {
+	Name     string        // Display name of the service.
+	Type     reflect.Type  // Type of the service.
+	Lifetime Lifetime      // Lifetime of the service.
+	Scope    string        // Scope holding the instance, empty unless the service is Scoped.
+	ThreadID string        // Thread ID holding the instance, empty unless the service is ThreadLocal.
+	Duration time.Duration // Time spent disposing the instance.
+	Err      error         // Error that occurred while disposing the instance, nil on success.
+}
+
+// notify calls the function for every observer of the registry.
+func (r *Registry) notify(event func(observer Observer)) {
+	for _, observer := range r.observers {
+		event(observer)
+	}
+}
+
+// observeRegister notifies the observers of a registration, or of a rejected registration when err is not nil.
+func (r *Registry) observeRegister(entry serviceEntry, opt *ResolutionOptions, err error) {
+	if len(r.observers) == 0 {
+		return
+	}
+
+	event := RegisterEvent{
+		Name:         internal.ServiceName(entry.typ),
+		Type:         entry.typ,
+		Lifetime:     entry.lifetime,
+		Registration: TypeRegistration,
+		Scope:        "",
+		ThreadID:     "",
+		Err:          err,
+	}
+
+	if entry.instance {
+		event.Registration = InstanceRegistration
+	}
+
+	switch entry.lifetime {
+	case Scoped:
+		event.Scope = opt.scope
+	case ThreadLocal:
+		event.ThreadID = opt.threadID
+	case Transient, Singleton, Pooled, Prototype:
+	}
+
+	r.notify(func(observer Observer) { observer.OnRegister(event) })
+}
+
+// observeResolve notifies the observers that the resolution of a service starts, and returns the function
+// notifying them that it ended.
+func (r *Registry) observeResolve(typ reflect.Type, lifetime Lifetime, opt *ResolutionOptions) func(bool, error) {
+	if len(r.observers) == 0 {
+		return func(bool, error) {}
+	}
+
+	start := time.Now()
+	event := ResolveEvent{
+		Name:     internal.ServiceName(typ),
+		Type:     typ,
+		Lifetime: lifetime,
+		Scope:    opt.scope,
+		ThreadID: "",
+		Chain:    slices.Clone(opt.chain),
+		Duration: 0,
+		CacheHit: false,
+		Err:      nil,
+	}
+
+	if lifetime == ThreadLocal {
+		event.ThreadID = opt.threadID
+	}
+
+	r.notify(func(observer Observer) { observer.OnResolveStart(event) })
+
+	return func(cacheHit bool, err error) {
+		event.Duration = time.Since(start)
+		event.CacheHit = cacheHit
+		event.Err = err
+
+		r.notify(func(observer Observer) { observer.OnResolveEnd(event) })
+	}
+}
+
+// observeCreate notifies the observers that an instance was created, or failed to be created when err is not nil.
+func (r *Registry) observeCreate(entry serviceEntry, opt *ResolutionOptions, duration time.Duration, err error) {
+	if len(r.observers) == 0 {
+		return
+	}
+
+	event := CreateEvent{
+		Name:     internal.ServiceName(entry.typ),
+		Type:     entry.typ,
+		Lifetime: entry.lifetime,
+		Scope:    opt.scope,
+		ThreadID: "",
+		Chain:    slices.Clone(opt.chain),
+		Factory:  entry.factory.IsValid(),
+		Duration: duration,
+		Err:      err,
+	}
+
+	if entry.lifetime == ThreadLocal {
+		event.ThreadID = opt.threadID
+	}
+
+	r.notify(func(observer Observer) { observer.OnCreate(event) })
+}
+
+// observeScopes notifies the observers that scopes were created or closed.
+func (r *Registry) observeScopes(created, closed []string) {
+	for _, scope := range created {
+		r.notify(func(observer Observer) { observer.OnScopeCreate(ScopeEvent{Scope: scope}) })
+	}
+
+	for _, scope := range closed {
+		r.notify(func(observer Observer) { observer.OnScopeClose(ScopeEvent{Scope: scope}) })
+	}
+}
+
+// observeDispose notifies the observers that an instance was disposed.
+func (r *Registry) observeDispose(inst heldInstance, duration time.Duration, err error) {
+	if len(r.observers) == 0 {
+		return
+	}
+
+	event := DisposeEvent{
+		Name:     internal.ServiceName(inst.typ),
+		Type:     inst.typ,
+		Lifetime: inst.lifetime,
+		Scope:    inst.scope,
+		ThreadID: inst.threadID,
+		Duration: duration,
+		Err:      err,
+	}
+
+	r.notify(func(observer Observer) { observer.OnDispose(event) })
+}
@@ -0,0 +1,154 @@
+package needle_test
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedleObserver struct {
+	needle.NopObserver
+
+	mutex     sync.Mutex
+	registers []needle.RegisterEvent
+	starts    []needle.ResolveEvent
+	ends      []needle.ResolveEvent
+	creates   []needle.CreateEvent
+	scopes    []string
+	disposals []needle.DisposeEvent
+}
+
+func (o *testNeedleObserver) OnRegister(event needle.RegisterEvent) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.registers = append(o.registers, event)
+}
+
+func (o *testNeedleObserver) OnResolveStart(event needle.ResolveEvent) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.starts = append(o.starts, event)
+}
+
+func (o *testNeedleObserver) OnResolveEnd(event needle.ResolveEvent) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.ends = append(o.ends, event)
+}
+
+func (o *testNeedleObserver) OnCreate(event needle.CreateEvent) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.creates = append(o.creates, event)
+}
+
+func (o *testNeedleObserver) OnScopeCreate(event needle.ScopeEvent) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.scopes = append(o.scopes, "create "+event.Scope)
+}
+
+func (o *testNeedleObserver) OnScopeClose(event needle.ScopeEvent) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.scopes = append(o.scopes, "close "+event.Scope)
+}
+
+func (o *testNeedleObserver) OnDispose(event needle.DisposeEvent) {
+	o.mutex.Lock()
+	defer o.mutex.Unlock()
+
+	o.disposals = append(o.disposals, event)
+}
+
+func TestNeedle_Observer_Register(t *testing.T) {
+	observer := &testNeedleObserver{} //nolint:exhaustruct
+	registry := needle.NewRegistry(needle.WithObserver(observer))
+
+	require.NoError(t, needle.RegisterToRegistry[testNeedleShutdownRepo](registry, needle.Singleton))
+	require.ErrorIs(t, needle.RegisterToRegistry[testNeedleShutdownRepo](registry, needle.Singleton), needle.ErrRegistered)
+	require.NoError(t, needle.RegisterScopedInstanceToRegistry(
+		registry, &testNeedleShutdownSession{}, needle.WithScope("request1"))) //nolint:exhaustruct
+
+	require.Len(t, observer.registers, 3)
+
+	assert.Equal(t, needle.Singleton, observer.registers[0].Lifetime)
+	assert.Equal(t, needle.TypeRegistration, observer.registers[0].Registration)
+	require.NoError(t, observer.registers[0].Err)
+	require.ErrorIs(t, observer.registers[1].Err, needle.ErrRegistered)
+	assert.Equal(t, needle.InstanceRegistration, observer.registers[2].Registration)
+	assert.Equal(t, "request1", observer.registers[2].Scope)
+	assert.Equal(t, []string{"create request1"}, observer.scopes)
+}
+
+func TestNeedle_Observer_Resolve(t *testing.T) {
+	observer := &testNeedleObserver{} //nolint:exhaustruct
+	registry := needle.NewRegistry(needle.WithObserver(observer))
+
+	instance := &testNeedleShutdownDatabase{} //nolint:exhaustruct
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, instance))
+	require.NoError(t, needle.RegisterToRegistry[testNeedleShutdownRepo](registry, needle.Singleton))
+
+	_, err := needle.ResolveFromRegistry[testNeedleShutdownRepo](registry)
+	require.NoError(t, err)
+
+	require.Len(t, observer.starts, 2)
+	require.Len(t, observer.ends, 2)
+
+	database, repo := observer.ends[0], observer.ends[1]
+
+	assert.Equal(t, "github.com/goplexhq/needle_test.testNeedleShutdownDatabase", database.Name)
+	assert.True(t, database.CacheHit)
+	require.Len(t, database.Chain, 1)
+	assert.Equal(t, repo.Type, database.Chain[0])
+	assert.False(t, repo.CacheHit)
+	assert.Empty(t, repo.Chain)
+	assert.Equal(t, needle.Singleton, repo.Lifetime)
+	assert.Positive(t, repo.Duration)
+
+	require.Len(t, observer.creates, 1)
+	assert.Equal(t, repo.Type, observer.creates[0].Type)
+	assert.False(t, observer.creates[0].Factory)
+	assert.Equal(t, []reflect.Type{repo.Type}, observer.creates[0].Chain)
+
+	_, err = needle.ResolveFromRegistry[testNeedleShutdownRepo](registry)
+	require.NoError(t, err)
+	assert.True(t, observer.ends[2].CacheHit)
+
+	_, err = needle.ResolveFromRegistry[testNeedleShutdownSession](registry)
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+	require.ErrorIs(t, observer.ends[3].Err, needle.ErrNotRegistered)
+}
+
+func TestNeedle_Observer_Dispose(t *testing.T) {
+	observer := &testNeedleObserver{} //nolint:exhaustruct
+	registry := needle.NewRegistry(needle.WithObserver(observer))
+	log := &testNeedleShutdownLog{} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testNeedleShutdownDatabase{log: log}))
+	require.NoError(t, needle.RegisterScopedInstanceToRegistry(
+		registry, &testNeedleCloser{name: "closer1"}, needle.WithScope("request1"))) //nolint:exhaustruct
+	require.NoError(t, needle.RegisterScopedInstanceToRegistry(
+		registry, &testNeedleCloser{name: "closer2"}, needle.WithScope("request2"))) //nolint:exhaustruct
+
+	require.NoError(t, needle.UnregisterFromRegistry[testNeedleCloser](registry, needle.WithScope("request1")))
+	require.Len(t, observer.disposals, 1)
+	assert.Equal(t, "request1", observer.disposals[0].Scope)
+	assert.Equal(t, needle.Scoped, observer.disposals[0].Lifetime)
+
+	_, err := registry.Shutdown(context.Background())
+	require.NoError(t, err)
+	require.Len(t, observer.disposals, 3)
+	assert.Equal(t, []string{"create request1", "create request2", "close request1", "close request2"}, observer.scopes)
+}
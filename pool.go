@@ -0,0 +1,55 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Resetter is implemented by pooled services clearing their state before they are returned to their pool.
+type Resetter interface {
+	Reset()
+}
+
+// Release returns a pooled instance resolved from the global registry to its pool.
+// See ReleaseToRegistry for details.
+func Release[T any](val *T) error {
+	ensureGlobalRegistryInitialized()
+
+	return ReleaseToRegistry(globalRegistry, val)
+}
+
+// ReleaseToRegistry returns an instance of a Pooled service resolved from the registry to its pool, calling its
+// Reset method first if it implements Resetter. The instance must not be used, nor released again, once released.
+// Returns an error if the service is not registered with the Pooled lifetime.
+//
+// Instances resolved within a scope are also returned to their pool when the scope is closed with
+// CloseScopeInRegistry, unless they were released already, and must not be released after the scope is closed.
+// Instances resolved outside a scope are not tracked by the registry, and are simply garbage collected if they are
+// never released.
+//
+// Example:
+//
+//	buf, err := needle.ResolveFromRegistry[Buffer](registry)
+//	if err != nil {
+//	    ...
+//	}
+//	defer needle.ReleaseToRegistry(registry, buf)
+func ReleaseToRegistry[T any](registry *Registry, val *T) error {
+	typ := reflect.TypeFor[T]()
+
+	entry, found := registry.has(typ)
+	if !found || entry.lifetime != Pooled {
+		return fmt.Errorf("%w: %s", ErrNotPooled, internal.ServiceName(typ))
+	}
+
+	pooled, ok := registry.strategies[Pooled].(*pooledStrategy)
+	if !ok || val == nil {
+		return nil
+	}
+
+	pooled.release(typ, val)
+
+	return nil
+}
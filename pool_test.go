@@ -0,0 +1,177 @@
+package needle_test
+
+import (
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedlePoolBuffer struct {
+	data   []byte
+	resets int
+}
+
+func (b *testNeedlePoolBuffer) Reset() {
+	b.data = b.data[:0]
+	b.resets++
+}
+
+func TestNeedle_PooledReuse(t *testing.T) {
+	const resolutions = 100
+
+	registry := needle.NewRegistry()
+	created := 0
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedlePoolBuffer](registry, needle.Pooled,
+		func() *testNeedlePoolBuffer {
+			created++
+
+			return &testNeedlePoolBuffer{data: make([]byte, 0, 1024)}
+		}))
+
+	for range resolutions {
+		buf, err := needle.ResolveFromRegistry[testNeedlePoolBuffer](registry)
+		require.NoError(t, err)
+		assert.Empty(t, buf.data)
+
+		buf.data = append(buf.data, "payload"...)
+
+		resets := buf.resets
+		require.NoError(t, needle.ReleaseToRegistry(registry, buf))
+		assert.Equal(t, resets+1, buf.resets)
+	}
+
+	assert.Less(t, created, resolutions) // the pool may drop idle instances, but reuses most of them
+}
+
+func TestNeedle_PooledReturnedOnScopeClose(t *testing.T) {
+	type Handler struct {
+		Buffer *testNeedlePoolBuffer `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[testNeedlePoolBuffer](registry, needle.Pooled))
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "request1"))
+	require.NoError(t, needle.RegisterToRegistry[Handler](registry, needle.Scoped, needle.WithScope("request1")))
+
+	handler, err := needle.ResolveFromRegistry[Handler](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+
+	released, err := needle.ResolveFromRegistry[testNeedlePoolBuffer](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+	require.NoError(t, needle.ReleaseToRegistry(registry, released))
+
+	require.NoError(t, needle.CloseScopeInRegistry(registry, "request1"))
+	assert.Equal(t, 1, handler.Buffer.resets)
+	assert.Equal(t, 1, released.resets) // not returned twice
+}
+
+func TestNeedle_PooledErrors(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Service struct{}
+
+	require.ErrorIs(t, needle.RegisterInstance(needle.Pooled, &Service{}), needle.ErrPooledInstance)
+	require.ErrorIs(t, needle.Release(&Service{}), needle.ErrNotPooled)
+
+	require.NoError(t, needle.Register[Service](needle.Pooled))
+	require.ErrorIs(t, needle.Register[Service](needle.Pooled), needle.ErrRegistered)
+	require.ErrorIs(t, needle.Replace(&Service{}), needle.ErrPooledInstance)
+}
+
+func TestNeedle_PooledLifetimes(t *testing.T) {
+	type Session struct{}
+
+	type Encoder struct {
+		Session *Session `needle:"inject"`
+	}
+
+	type Service struct {
+		Buffer *testNeedlePoolBuffer `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[testNeedlePoolBuffer](registry, needle.Pooled))
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.Singleton))
+	require.NoError(t, registry.Validate())
+
+	require.NoError(t, needle.RegisterToRegistry[Encoder](registry, needle.Pooled))
+	require.NoError(t, needle.RegisterToRegistry[Session](registry, needle.Scoped, needle.WithScope("request1")))
+
+	err := registry.Validate()
+	require.ErrorIs(t, err, needle.ErrCaptiveDependency)
+	assert.Contains(t, err.Error(), "POOLED service depends on SCOPED service")
+}
+
+func TestNeedle_PooledDroppedOnRemoval(t *testing.T) {
+	type Conn struct{ factory string }
+
+	registerFactory := func(t *testing.T, registry *needle.Registry, factory string) {
+		t.Helper()
+
+		require.NoError(t, needle.RegisterFactoryToRegistry[Conn](registry, needle.Pooled, func() *Conn {
+			return &Conn{factory: factory}
+		}))
+	}
+
+	for name, remove := range map[string]func(*needle.Registry) error{
+		"unregister": func(registry *needle.Registry) error {
+			return needle.UnregisterFromRegistry[Conn](registry)
+		},
+		"reset": func(registry *needle.Registry) error {
+			registry.Reset()
+
+			return nil
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			registry := needle.NewRegistry()
+			registerFactory(t, registry, "old")
+
+			conn, err := needle.ResolveFromRegistry[Conn](registry)
+			require.NoError(t, err)
+			require.NoError(t, needle.ReleaseToRegistry(registry, conn))
+
+			require.NoError(t, remove(registry))
+			registerFactory(t, registry, "new")
+
+			conn, err = needle.ResolveFromRegistry[Conn](registry)
+			require.NoError(t, err)
+			assert.Equal(t, "new", conn.factory)
+		})
+	}
+}
+
+func TestNeedle_PooledReleaseOnce(t *testing.T) {
+	type Service struct{}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.Transient))
+	require.NoError(t, needle.RegisterToRegistry[testNeedlePoolBuffer](registry, needle.Pooled))
+
+	service, err := needle.ResolveFromRegistry[Service](registry)
+	require.NoError(t, err)
+	require.ErrorIs(t, needle.ReleaseToRegistry(registry, service), needle.ErrNotPooled)
+
+	buf, err := needle.ResolveFromRegistry[testNeedlePoolBuffer](registry)
+	require.NoError(t, err)
+	require.NoError(t, needle.ReleaseToRegistry(registry, buf))
+	assert.Equal(t, 1, buf.resets)
+
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "request1"))
+
+	released, err := needle.ResolveFromRegistry[testNeedlePoolBuffer](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+
+	closed, err := needle.ResolveFromRegistry[testNeedlePoolBuffer](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+
+	releasedResets, closedResets := released.resets, closed.resets
+
+	require.NoError(t, needle.ReleaseToRegistry(registry, released))
+	require.NoError(t, needle.CloseScopeInRegistry(registry, "request1"))
+	assert.Equal(t, releasedResets+1, released.resets) // not returned again when the scope is closed
+	assert.Equal(t, closedResets+1, closed.resets)
+}
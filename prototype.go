@@ -0,0 +1,60 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Cloner is implemented by the templates of prototypes copying themselves, and is preferred over the copy made by
+// the registry.
+//
+// Example:
+//
+//	func (c *Client) Clone() *Client {
+//	    clone := *c
+//	    clone.Headers = maps.Clone(c.Headers)
+//
+//	    return &clone
+//	}
+type Cloner[T any] interface {
+	Clone() *T
+}
+
+// WithDeepCopy makes the registry deeply copy the template of a prototype on every resolution, instead of copying
+// only its top-level fields, unless the template implements Cloner. Only used when registering a Prototype.
+//
+// Example:
+//
+//	err := needle.RegisterInstance(needle.Prototype, &Request{Headers: defaults}, needle.WithDeepCopy())
+func WithDeepCopy() ResolutionOptionFunc {
+	return func(o *ResolutionOptions) {
+		o.deepCopy = true
+	}
+}
+
+// clonePrototype returns a copy of the template of a prototype: the result of its Clone method if it implements
+// Cloner, otherwise a deep or shallow copy.
+func clonePrototype(entry serviceEntry) (reflect.Value, error) {
+	if method := entry.template.MethodByName("Clone"); method.IsValid() {
+		methodType := method.Type()
+		if methodType.NumIn() == 0 && methodType.NumOut() == 1 && methodType.Out(0) == entry.template.Type() {
+			clone := method.Call(nil)[0]
+			if clone.IsNil() {
+				return reflect.Value{}, fmt.Errorf("%w: %s", ErrNilClone, entry.name)
+			}
+
+			return clone, nil
+		}
+	}
+
+	if entry.deepCopy {
+		return internal.DeepCopy(entry.template), nil
+	}
+
+	clone := reflect.New(entry.typ)
+	clone.Elem().Set(entry.template.Elem())
+
+	return clone, nil
+}
@@ -0,0 +1,129 @@
+package needle_test
+
+import (
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedlePrototypeRequest struct {
+	Method  string
+	Headers map[string]string
+	retries int
+}
+
+type testNeedlePrototypeClient struct {
+	BaseURL string
+	clones  *int
+}
+
+func (c *testNeedlePrototypeClient) Clone() *testNeedlePrototypeClient {
+	*c.clones++
+
+	return &testNeedlePrototypeClient{BaseURL: c.BaseURL + "/v2", clones: c.clones}
+}
+
+func TestNeedle_PrototypeShallowCopy(t *testing.T) {
+	registry := needle.NewRegistry()
+	template := &testNeedlePrototypeRequest{Method: "GET", Headers: map[string]string{"Accept": "json"}, retries: 3}
+
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Prototype, template))
+
+	first, err := needle.ResolveFromRegistry[testNeedlePrototypeRequest](registry)
+	require.NoError(t, err)
+
+	second, err := needle.ResolveFromRegistry[testNeedlePrototypeRequest](registry)
+	require.NoError(t, err)
+
+	assert.NotSame(t, template, first)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, *template, *first)
+	assert.Equal(t, 3, first.retries)
+
+	first.Method = "POST"
+	first.Headers["Accept"] = "xml"
+
+	assert.Equal(t, "GET", template.Method)
+	assert.Equal(t, "xml", template.Headers["Accept"]) // maps are shared by shallow copies
+}
+
+func TestNeedle_PrototypeDeepCopy(t *testing.T) {
+	registry := needle.NewRegistry()
+	template := &testNeedlePrototypeRequest{Method: "GET", Headers: map[string]string{"Accept": "json"}}
+
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Prototype, template, needle.WithDeepCopy()))
+
+	request, err := needle.ResolveFromRegistry[testNeedlePrototypeRequest](registry)
+	require.NoError(t, err)
+
+	request.Headers["Accept"] = "xml"
+
+	assert.Equal(t, "json", template.Headers["Accept"])
+}
+
+func TestNeedle_PrototypeCloner(t *testing.T) {
+	registry := needle.NewRegistry()
+	clones := 0
+	template := &testNeedlePrototypeClient{BaseURL: "https://api", clones: &clones}
+
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Prototype, template, needle.WithDeepCopy()))
+
+	client, err := needle.ResolveFromRegistry[testNeedlePrototypeClient](registry)
+	require.NoError(t, err)
+	assert.Equal(t, "https://api/v2", client.BaseURL)
+	assert.Equal(t, 1, clones)
+}
+
+func TestNeedle_PrototypeInjected(t *testing.T) {
+	type Handler struct {
+		Request *testNeedlePrototypeRequest `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Prototype,
+		&testNeedlePrototypeRequest{Method: "GET"}))
+	require.NoError(t, needle.RegisterToRegistry[Handler](registry, needle.Singleton))
+	require.NoError(t, registry.Validate())
+
+	handler, err := needle.ResolveFromRegistry[Handler](registry)
+	require.NoError(t, err)
+	assert.Equal(t, "GET", handler.Request.Method)
+}
+
+func TestNeedle_PrototypeReplaceTemplate(t *testing.T) {
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Prototype,
+		&testNeedlePrototypeRequest{Method: "GET"}))
+
+	require.NoError(t, needle.ReplaceInRegistry(registry, &testNeedlePrototypeRequest{Method: "PUT"}))
+
+	request, err := needle.ResolveFromRegistry[testNeedlePrototypeRequest](registry)
+	require.NoError(t, err)
+	assert.Equal(t, "PUT", request.Method)
+}
+
+func TestNeedle_PrototypeRequiresTemplate(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	err := needle.RegisterToRegistry[testNeedlePrototypeRequest](registry, needle.Prototype)
+	require.ErrorIs(t, err, needle.ErrPrototypeTemplate)
+
+	err = needle.RegisterFactoryToRegistry[testNeedlePrototypeRequest](registry, needle.Prototype,
+		func() *testNeedlePrototypeRequest { return &testNeedlePrototypeRequest{} })
+	require.ErrorIs(t, err, needle.ErrPrototypeTemplate)
+
+	err = needle.RegisterInstanceToRegistry(registry, needle.Prototype, (*testNeedlePrototypeRequest)(nil))
+	require.ErrorIs(t, err, needle.ErrPrototypeTemplate)
+	assert.Empty(t, registry.RegisteredServices())
+
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Prototype,
+		&testNeedlePrototypeRequest{Method: "GET"}))
+	require.ErrorIs(t, needle.ReplaceInRegistry(registry, (*testNeedlePrototypeRequest)(nil)),
+		needle.ErrPrototypeTemplate)
+
+	request, err := needle.ResolveFromRegistry[testNeedlePrototypeRequest](registry)
+	require.NoError(t, err)
+	assert.Equal(t, "GET", request.Method)
+}
@@ -0,0 +1,149 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Provider resolves instances of a service on demand. Inject a *Provider[T] instead of a *T into a longer-lived
+// service to resolve a shorter-lived service, such as a scoped service from a singleton, without capturing it.
+//
+// Example:
+//
+//	type Handler struct {
+//	    Sessions *needle.Provider[Session] `needle:"inject"`
+//	}
+//
+//	session, err := h.Sessions.Get(needle.WithScope(requestID))
+type Provider[T any] struct {
+	registry *Registry
+}
+
+// Get resolves an instance of the service from the registry the provider was injected from.
+func (p *Provider[T]) Get(optFuncs ...ResolutionOptionFunc) (*T, error) {
+	return ResolveFromRegistry[T](p.registry, optFuncs...)
+}
+
+// bind implements deferred.
+func (p *Provider[T]) bind(registry *Registry, _ *ResolutionOptions) {
+	p.registry = registry
+}
+
+// Lazy resolves an instance of a service on first use, with the scope and thread ID of the resolution it was
+// injected in, and returns the same instance afterward. Failed resolutions are retried on the next call. Unlike a
+// Provider, a Lazy of a shorter-lived service is a captive dependency.
+//
+// Example:
+//
+//	type Service struct {
+//	    Cache *needle.Lazy[Cache] `needle:"inject"`
+//	}
+//
+//	cache, err := s.Cache.Get()
+type Lazy[T any] struct {
+	registry *Registry
+	opt      *ResolutionOptions
+	value    *T
+	lock     sync.Mutex
+}
+
+// Get resolves the instance of the service on first call, and returns it afterward.
+func (l *Lazy[T]) Get() (*T, error) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if l.value != nil {
+		return l.value, nil
+	}
+
+	typ := reflect.TypeFor[T]()
+	opt := *l.opt
+
+	inst, err := resolveType(l.registry, typ, &opt)
+	if err != nil {
+		return nil, err
+	}
+
+	value, valid := inst.(*T)
+	if !valid {
+		return nil, fmt.Errorf("%w: %s", ErrServiceTypeMismatch, internal.ServiceName(typ))
+	}
+
+	l.value = value
+
+	return value, nil
+}
+
+// captured implements captor.
+func (*Lazy[T]) captured() reflect.Type {
+	return reflect.TypeFor[T]()
+}
+
+// bind implements deferred.
+func (l *Lazy[T]) bind(registry *Registry, opt *ResolutionOptions) {
+	l.registry = registry
+	l.opt = newResolutionOptions(WithScope(opt.scope), WithThreadID(opt.threadID))
+}
+
+// deferred is implemented by the wrappers resolving a service after injection, i.e. Provider and Lazy.
+type deferred interface {
+	bind(registry *Registry, opt *ResolutionOptions)
+}
+
+//nolint:gochecknoglobals
+var deferredType = reflect.TypeFor[deferred]()
+
+// captor is implemented by the wrappers keeping the scope and thread ID of the resolution they were injected in,
+// i.e. Lazy, which capture their service as a direct dependency does.
+type captor interface {
+	captured() reflect.Type
+}
+
+// isDeferred reports whether pointers to a type are wrappers resolving a service after injection.
+func isDeferred(typ reflect.Type) bool {
+	return reflect.PointerTo(typ).Implements(deferredType)
+}
+
+// resolvedBy returns the service resolved when injecting a pointer to a type, or nil for wrappers.
+func resolvedBy(typ reflect.Type) reflect.Type {
+	if isDeferred(typ) {
+		return nil
+	}
+
+	return typ
+}
+
+// capturedBy returns the service captured by a wrapper when injecting a pointer to a type, or nil if none is.
+func capturedBy(typ reflect.Type) reflect.Type {
+	if !isDeferred(typ) {
+		return nil
+	}
+
+	if wrapper, ok := reflect.New(typ).Interface().(captor); ok {
+		return wrapper.captured()
+	}
+
+	return nil
+}
+
+// resolveDependency resolves the value injected for a pointer to the given type: wrappers are created and bound
+// to the registry, other types are resolved from the registry.
+func resolveDependency(registry *Registry, typ reflect.Type, opt *ResolutionOptions) (any, error) {
+	if isDeferred(typ) {
+		if err := registry.checkCaptured(typ, opt); err != nil {
+			return nil, err
+		}
+
+		value := reflect.New(typ)
+
+		wrapper, _ := value.Interface().(deferred)
+		wrapper.bind(registry, opt)
+
+		return wrapper, nil
+	}
+
+	return resolveType(registry, typ, opt)
+}
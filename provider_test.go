@@ -0,0 +1,110 @@
+package needle_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedle_ProviderResolvesOnDemand(t *testing.T) {
+	type Session struct{ id string }
+
+	type Handler struct {
+		Sessions *needle.Provider[Session] `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[Handler](registry, needle.Singleton))
+	require.NoError(t, needle.RegisterToRegistry[Session](registry, needle.Scoped, needle.WithScope("a")))
+	require.NoError(t, needle.RegisterToRegistry[Session](registry, needle.Scoped, needle.WithScope("b")))
+
+	handler, err := needle.ResolveFromRegistry[Handler](registry)
+	require.NoError(t, err)
+	require.NotNil(t, handler.Sessions)
+
+	sessionA, err := handler.Sessions.Get(needle.WithScope("a"))
+	require.NoError(t, err)
+
+	sessionA.id = "a"
+
+	sessionB, err := handler.Sessions.Get(needle.WithScope("b"))
+	require.NoError(t, err)
+	assert.Empty(t, sessionB.id)
+
+	again, err := handler.Sessions.Get(needle.WithScope("a"))
+	require.NoError(t, err)
+	assert.Same(t, sessionA, again)
+
+	_, err = handler.Sessions.Get()
+	require.ErrorIs(t, err, needle.ErrEmptyScope)
+	require.NoError(t, registry.Validate())
+}
+
+func TestNeedle_LazyResolvesOnFirstUse(t *testing.T) {
+	type Cache struct{}
+
+	type Service struct {
+		Cache *needle.Lazy[Cache] `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	calls := 0
+	failing := true
+
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.Transient))
+	require.NoError(t, needle.RegisterFactoryToRegistry[Cache](registry, needle.Scoped, func() (*Cache, error) {
+		calls++
+
+		if failing {
+			return nil, errors.New("unavailable")
+		}
+
+		return &Cache{}, nil
+	}, needle.WithScope("request1")))
+
+	service, err := needle.ResolveFromRegistry[Service](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+	assert.Zero(t, calls)
+
+	_, err = service.Cache.Get()
+	require.ErrorIs(t, err, needle.ErrFactory)
+
+	failing = false
+
+	cache, err := service.Cache.Get()
+	require.NoError(t, err)
+
+	again, err := service.Cache.Get()
+	require.NoError(t, err)
+	assert.Same(t, cache, again)
+	assert.Equal(t, 2, calls)
+
+	scoped, err := needle.ResolveFromRegistry[Cache](registry, needle.WithScope("request1"))
+	require.NoError(t, err)
+	assert.Same(t, cache, scoped)
+}
+
+func TestNeedle_LazyFactoryParameter(t *testing.T) {
+	type Config struct{ name string }
+
+	type Service struct {
+		config *needle.Lazy[Config]
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Singleton, &Config{name: "config"}))
+	require.NoError(t, needle.RegisterFactoryToRegistry[Service](registry, needle.Singleton,
+		func(config *needle.Lazy[Config]) *Service {
+			return &Service{config: config}
+		}))
+
+	service, err := needle.ResolveFromRegistry[Service](registry)
+	require.NoError(t, err)
+
+	config, err := service.config.Get()
+	require.NoError(t, err)
+	assert.Equal(t, "config", config.name)
+}
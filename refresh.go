@@ -0,0 +1,171 @@
+package needle
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// WithTTL sets the time to live of the instance of a singleton created by the registry. Once expired, the
+// instance is created again by the next resolution, and the expired instance is disposed one TTL later, giving
+// the services still using it time to stop. Only used when registering a service.
+//
+// Example:
+//
+//	err := needle.RegisterFactory[Token](needle.Singleton, fetchToken, needle.WithTTL(time.Hour))
+func WithTTL(ttl time.Duration) ResolutionOptionFunc {
+	return func(o *ResolutionOptions) {
+		o.ttl = ttl
+	}
+}
+
+// WithRefresh sets the interval at which the instance of a singleton created by the registry is created again in
+// the background, once first resolved. Resolutions keep returning the previous instance until a new instance is
+// created, and the previous instance is disposed one interval later, giving the services still using it time to
+// stop. Failed refreshes keep the previous instance. Only used when registering a service.
+//
+// Example:
+//
+//	err := needle.RegisterFactory[Flags](needle.Singleton, loadFlags, needle.WithRefresh(30*time.Second))
+func WithRefresh(interval time.Duration) ResolutionOptionFunc {
+	return func(o *ResolutionOptions) {
+		o.refresh = interval
+	}
+}
+
+// expired reports whether an instance of the service outlived the TTL of the service.
+func (e *serviceEntry) expired(inst serviceInstance) bool {
+	return e.ttl > 0 && inst.value.IsValid() && time.Since(inst.createdAt) >= e.ttl
+}
+
+// startRefresh starts refreshing the instance of a singleton in the background, unless it is already refreshed.
+func (r *Registry) startRefresh(entry serviceEntry) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if _, started := r.refreshers[entry.typ]; started {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.refreshers[entry.typ] = cancel
+
+	go r.refreshLoop(ctx, entry)
+}
+
+// refreshLoop creates the instance of a singleton again at every refresh interval until the context is canceled,
+// which happens when the service is unregistered, or until the registry no longer holds its instance.
+func (r *Registry) refreshLoop(ctx context.Context, entry serviceEntry) {
+	ticker := time.NewTicker(entry.refresh)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		value, err := construct(r, entry, newResolutionOptions())
+		if err != nil {
+			continue
+		}
+
+		old, swapped := r.swap(ctx, entry, value)
+		if !swapped {
+			_ = r.dispose(newHeldInstance(entry.typ, Singleton, "", value))
+
+			r.stopRefresh(ctx, entry.typ)
+
+			return
+		}
+
+		r.retire(entry, old)
+	}
+}
+
+// stopRefresh forgets the background refresh of a singleton, unless its context was canceled, in which case the
+// refresh was already forgotten and may have been started again.
+func (r *Registry) stopRefresh(ctx context.Context, typ reflect.Type) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if ctx.Err() == nil {
+		r.cancelRefresh(typ)
+	}
+}
+
+// cancelRefresh stops refreshing the instance of a singleton, if refreshed.
+// The caller must hold the registry lock.
+func (r *Registry) cancelRefresh(typ reflect.Type) {
+	if cancel, refreshed := r.refreshers[typ]; refreshed {
+		cancel()
+		delete(r.refreshers, typ)
+	}
+}
+
+// swap replaces the created instance of a singleton with a refreshed instance, and returns the previous instance.
+// Returns false when the singleton holds no created instance anymore, or the refresh was canceled meanwhile, e.g.
+// because the instance was replaced.
+func (r *Registry) swap(ctx context.Context, entry serviceEntry, value reflect.Value) (heldInstance, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if ctx.Err() != nil {
+		return heldInstance{}, false
+	}
+
+	old, exists := r.instances[Singleton][""][entry.typ]
+	if !exists || !old.value.IsValid() {
+		return heldInstance{}, false
+	}
+
+	r.hold(Singleton, "", entry.typ, serviceInstance{value: value, createdAt: time.Now()})
+
+	return newHeldInstance(entry.typ, Singleton, "", old.value), true
+}
+
+// retire disposes an expired or refreshed instance once the TTL or refresh interval of the service elapsed again.
+// Instances still waiting to be disposed are disposed by Shutdown.
+func (r *Registry) retire(entry serviceEntry, inst heldInstance) {
+	delay := max(entry.ttl, entry.refresh)
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var timer *time.Timer
+
+	timer = time.AfterFunc(delay, func() {
+		r.lock.Lock()
+		_, pending := r.retired[timer]
+		delete(r.retired, timer)
+		r.lock.Unlock()
+
+		if pending {
+			_ = r.dispose(inst)
+		}
+	})
+
+	r.retired[timer] = inst
+}
+
+// stopRefreshes stops every background refresh and pending disposal, and returns the instances that were waiting
+// to be disposed.
+// The caller must hold the registry lock.
+func (r *Registry) stopRefreshes() []heldInstance {
+	for typ, cancel := range r.refreshers {
+		cancel()
+		delete(r.refreshers, typ)
+	}
+
+	retired := make([]heldInstance, 0, len(r.retired))
+
+	for timer, inst := range r.retired {
+		timer.Stop()
+		delete(r.retired, timer)
+
+		retired = append(retired, inst)
+	}
+
+	return retired
+}
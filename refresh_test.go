@@ -0,0 +1,159 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedleRefreshToken struct {
+	version int64
+	closed  atomic.Bool
+}
+
+func (t *testNeedleRefreshToken) Close() error {
+	t.closed.Store(true)
+
+	return nil
+}
+
+func TestNeedle_TTLRecreatesExpiredSingleton(t *testing.T) {
+	const ttl = 50 * time.Millisecond
+
+	registry := needle.NewRegistry()
+
+	var created atomic.Int64
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleRefreshToken](registry, needle.Singleton,
+		func() *testNeedleRefreshToken {
+			return &testNeedleRefreshToken{version: created.Add(1)}
+		}, needle.WithTTL(ttl)))
+
+	first, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+	require.NoError(t, err)
+
+	again, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+	require.NoError(t, err)
+	assert.Same(t, first, again)
+
+	time.Sleep(ttl)
+
+	second, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, int64(2), second.version)
+	assert.False(t, first.closed.Load(), "expired instance disposed before its grace period")
+
+	assert.Eventually(t, first.closed.Load, time.Second, 10*time.Millisecond)
+	assert.False(t, second.closed.Load())
+}
+
+func TestNeedle_RefreshInBackground(t *testing.T) {
+	const interval = 20 * time.Millisecond
+
+	registry := needle.NewRegistry()
+
+	var created atomic.Int64
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleRefreshToken](registry, needle.Singleton,
+		func() (*testNeedleRefreshToken, error) {
+			version := created.Add(1)
+			if version == 2 {
+				return nil, errors.New("token endpoint unavailable") //nolint:err113
+			}
+
+			return &testNeedleRefreshToken{version: version}, nil
+		}, needle.WithRefresh(interval)))
+
+	first, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), first.version)
+
+	// the second refresh fails and keeps the first instance, the third one replaces it
+	assert.Eventually(t, func() bool {
+		current, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+
+		return err == nil && current.version >= 3
+	}, time.Second, 5*time.Millisecond)
+
+	assert.Eventually(t, first.closed.Load, time.Second, 5*time.Millisecond)
+
+	report, err := registry.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, report.Services)
+
+	refreshes := created.Load()
+
+	time.Sleep(5 * interval)
+	assert.Equal(t, refreshes, created.Load(), "refreshed after shutdown")
+}
+
+func TestNeedle_ShutdownDisposesRetiredInstances(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleRefreshToken](registry, needle.Singleton,
+		func() *testNeedleRefreshToken {
+			return &testNeedleRefreshToken{}
+		}, needle.WithTTL(time.Millisecond)))
+
+	first, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+	require.NoError(t, err)
+
+	time.Sleep(2 * time.Millisecond)
+
+	second, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+	require.NoError(t, err)
+	require.NotSame(t, first, second)
+
+	_, err = registry.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.True(t, first.closed.Load())
+	assert.True(t, second.closed.Load())
+}
+
+func TestNeedle_RefreshRejected(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	err := needle.RegisterToRegistry[testNeedleRefreshToken](registry, needle.Scoped,
+		needle.WithScope("request1"), needle.WithTTL(time.Minute))
+	require.ErrorIs(t, err, needle.ErrRefresh)
+
+	err = needle.RegisterInstanceToRegistry(registry, needle.Singleton, &testNeedleRefreshToken{},
+		needle.WithRefresh(time.Minute))
+	require.ErrorIs(t, err, needle.ErrRefresh)
+}
+
+func TestNeedle_ReplaceStopsRefresh(t *testing.T) {
+	for name, option := range map[string]needle.ResolutionOptionFunc{
+		"ttl":     needle.WithTTL(20 * time.Millisecond),
+		"refresh": needle.WithRefresh(20 * time.Millisecond),
+	} {
+		t.Run(name, func(t *testing.T) {
+			registry := needle.NewRegistry()
+
+			require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleRefreshToken](registry, needle.Singleton,
+				func() *testNeedleRefreshToken {
+					return &testNeedleRefreshToken{}
+				}, option))
+
+			_, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+			require.NoError(t, err)
+
+			mock := &testNeedleRefreshToken{version: -1}
+			require.NoError(t, needle.ReplaceInRegistry(registry, mock))
+
+			time.Sleep(80 * time.Millisecond)
+
+			current, err := needle.ResolveFromRegistry[testNeedleRefreshToken](registry)
+			require.NoError(t, err)
+			assert.Same(t, mock, current)
+			assert.False(t, mock.closed.Load())
+		})
+	}
+}
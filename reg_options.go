@@ -0,0 +1,139 @@
+package needle
+
+import (
+	"log/slog"
+	"time"
+)
+
+// RegistryOptions holds configuration options for creating a registry.
+type RegistryOptions struct {
+	observers     []Observer
+	logger        *slog.Logger
+	slowThreshold time.Duration
+	tracer        Tracer
+	strict        bool
+	lifetimes     map[Lifetime]LifetimeStrategy
+	retry         retryPolicy
+}
+
+// retryPolicy defines how failed factory calls are retried.
+type retryPolicy struct {
+	attempts   int
+	minBackoff time.Duration
+	maxBackoff time.Duration
+}
+
+// RegistryOptionFunc is a function that modifies a RegistryOptions struct.
+type RegistryOptionFunc func(*RegistryOptions)
+
+// WithObserver adds an observer receiving the events of a registry. Observers are notified in the order they
+// are added.
+//
+// Example:
+//
+//	registry := needle.NewRegistry(needle.WithObserver(&MyObserver{}))
+func WithObserver(observer Observer) RegistryOptionFunc {
+	return func(o *RegistryOptions) {
+		o.observers = append(o.observers, observer)
+	}
+}
+
+// WithLogger sets the logger of a registry. The registry logs registrations, rejected registrations, resolution
+// failures, slow service creations, scopes and disposals, with the service, lifetime, scope and thread as
+// attributes. By default, the registry does not log.
+//
+// Example:
+//
+//	registry := needle.NewRegistry(needle.WithLogger(slog.Default()))
+func WithLogger(logger *slog.Logger) RegistryOptionFunc {
+	return func(o *RegistryOptions) {
+		o.logger = logger
+	}
+}
+
+// WithSlowThreshold sets the duration above which creating a service instance is logged as slow by the logger
+// set with WithLogger. Zero disables the warning. Defaults to 100ms.
+//
+// Example:
+//
+//	registry := needle.NewRegistry(needle.WithLogger(logger), needle.WithSlowThreshold(time.Second))
+func WithSlowThreshold(threshold time.Duration) RegistryOptionFunc {
+	return func(o *RegistryOptions) {
+		o.slowThreshold = threshold
+	}
+}
+
+// WithTracer sets the tracer starting spans around the resolutions and factory calls of a registry.
+// Use WithContext to start the spans of a resolution in an existing trace.
+//
+// Example:
+//
+//	registry := needle.NewRegistry(needle.WithTracer(tracer))
+func WithTracer(tracer Tracer) RegistryOptionFunc {
+	return func(o *RegistryOptions) {
+		o.tracer = tracer
+	}
+}
+
+// WithStrictLifetimes makes resolutions fail with ErrCaptiveDependency when a service would capture a
+// shorter-lived dependency, such as a Singleton injected with a Scoped service. See Registry.Validate for the
+// rules. By default, captive dependencies are only reported by Validate.
+//
+// Example:
+//
+//	registry := needle.NewRegistry(needle.WithStrictLifetimes())
+func WithStrictLifetimes() RegistryOptionFunc {
+	return func(o *RegistryOptions) {
+		o.strict = true
+	}
+}
+
+// WithLifetime adds a custom lifetime to a registry, whose instances are keyed, cached, created and disposed by
+// the given strategy. Built-in lifetimes cannot be replaced, and a strategy cannot be nil: the registrations,
+// Validate and Build of a registry created with either fail with ErrBuiltinLifetime or ErrNilStrategy.
+//
+// Example:
+//
+//	const PerTenant needle.Lifetime = "PER_TENANT"
+//
+//	registry := needle.NewRegistry(needle.WithLifetime(PerTenant, &TenantStrategy{}))
+//	err := needle.RegisterToRegistry[Database](registry, PerTenant)
+func WithLifetime(lifetime Lifetime, strategy LifetimeStrategy) RegistryOptionFunc {
+	return func(o *RegistryOptions) {
+		if o.lifetimes == nil {
+			o.lifetimes = make(map[Lifetime]LifetimeStrategy)
+		}
+
+		o.lifetimes[lifetime] = strategy
+	}
+}
+
+// WithFactoryRetry makes a registry retry failed factory calls, up to the given number of attempts in total,
+// before failing the resolution. The delay between attempts starts at minBackoff and doubles after each failure
+// up to maxBackoff. Waiting stops once the context set with WithContext is done. By default, factory calls are not
+// retried, and failures are never kept: the next resolution calls the factory again.
+//
+// Example:
+//
+//	registry := needle.NewRegistry(needle.WithFactoryRetry(3, 100*time.Millisecond, time.Second))
+func WithFactoryRetry(attempts int, minBackoff, maxBackoff time.Duration) RegistryOptionFunc {
+	return func(o *RegistryOptions) {
+		o.retry = retryPolicy{attempts: attempts, minBackoff: minBackoff, maxBackoff: max(minBackoff, maxBackoff)}
+	}
+}
+
+// newRegistryOptions creates a new RegistryOptions struct from the provided option functions.
+func newRegistryOptions(optFuncs ...RegistryOptionFunc) *RegistryOptions {
+	opt := &RegistryOptions{ //nolint:exhaustruct
+		slowThreshold: defaultSlowThreshold,
+	}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	if opt.logger != nil {
+		opt.observers = append(opt.observers, &loggingObserver{logger: opt.logger, slowThreshold: opt.slowThreshold})
+	}
+
+	return opt
+}
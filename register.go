@@ -8,7 +8,8 @@ import (
 )
 
 // Register registers a type with a specified lifetime to the global registry.
-// Returns an error if the type is already registered or invalid.
+// Returns an error if the type is already registered or invalid. See RegisterToRegistry for how instances are
+// created.
 //
 // The optFuncs parameter allows for optional configuration of the registration, such as setting a scope or thread ID.
 //
@@ -46,6 +47,11 @@ func Register[T any](lifetime Lifetime, optFuncs ...ResolutionOptionFunc) error
 // RegisterToRegistry registers a type with a specified lifetime to the registry.
 // Returns an error if the type is already registered or invalid.
 //
+// Instances are not allocated on registration: they are created on first resolution, or by Registry.Build, with
+// their needle:"inject" fields injected and their PostConstruct and Validate hooks run, so resolving them fails
+// while a tagged dependency is not registered. Register a zero value with RegisterInstanceToRegistry to skip
+// injection.
+//
 // The optFuncs parameter allows for optional configuration of the registration, such as setting a scope or thread ID.
 //
 // Available options:
@@ -77,6 +83,56 @@ func Register[T any](lifetime Lifetime, optFuncs ...ResolutionOptionFunc) error
 //	    ...
 //	}
 func RegisterToRegistry[T any](registry *Registry, lifetime Lifetime, optFuncs ...ResolutionOptionFunc) error {
+	return RegisterTypeToRegistry(registry, reflect.TypeFor[T](), lifetime, optFuncs...)
+}
+
+// RegisterType registers a type discovered at runtime with a specified lifetime to the global registry.
+// Returns an error if the type is already registered or invalid. Instances are created as with
+// RegisterToRegistry.
+//
+// The optFuncs parameter allows for optional configuration of the registration, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for scoped instances. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for thread-local instances. Optional and defaults
+//     to the current goroutine ID if not provided and the lifetime is ThreadLocal.
+//
+// Example:
+//
+//	err := needle.RegisterType(reflect.TypeFor[MyService](), needle.Singleton)
+//	if err != nil {
+//	    ...
+//	}
+func RegisterType(typ reflect.Type, lifetime Lifetime, optFuncs ...ResolutionOptionFunc) error {
+	ensureGlobalRegistryInitialized()
+
+	return RegisterTypeToRegistry(globalRegistry, typ, lifetime, optFuncs...)
+}
+
+// RegisterTypeToRegistry registers a type discovered at runtime with a specified lifetime to the registry.
+// Returns an error if the type is already registered or invalid. Instances are created as with
+// RegisterToRegistry.
+//
+// The optFuncs parameter allows for optional configuration of the registration, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for scoped instances. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for thread-local instances. Optional and defaults
+//     to the current goroutine ID if not provided and the lifetime is ThreadLocal.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	err := needle.RegisterTypeToRegistry(registry, reflect.TypeFor[MyService](), needle.Singleton)
+//	if err != nil {
+//	    ...
+//	}
+func RegisterTypeToRegistry(
+	registry *Registry,
+	typ reflect.Type,
+	lifetime Lifetime,
+	optFuncs ...ResolutionOptionFunc,
+) error {
 	opt := newResolutionOptions(optFuncs...)
 
 	if lifetime == Scoped && opt.scope == "" {
@@ -87,22 +143,11 @@ func RegisterToRegistry[T any](registry *Registry, lifetime Lifetime, optFuncs .
 		opt.threadID = internal.GetGoroutineID()
 	}
 
-	typ, name, err := ensureRegistrable[T](registry, lifetime, opt)
-	if err != nil {
-		return err
+	if typ != nil && typ.Kind() == reflect.Interface {
+		return fmt.Errorf("%w: %s", ErrInvalidServiceType, internal.ServiceName(typ))
 	}
 
-	var value reflect.Value
-
-	if lifetime == Transient {
-		value = reflect.Zero(typ)
-	} else {
-		value = reflect.New(typ)
-	}
-
-	registry.set(name, lifetime, value, opt)
-
-	return nil
+	return registry.register(serviceEntry{typ: typ, lifetime: lifetime}, reflect.Value{}, opt) //nolint:exhaustruct
 }
 
 // RegisterInstance registers a pre-initialized instance with a specified lifetime to the global registry.
@@ -146,6 +191,7 @@ func RegisterInstance[T any](lifetime Lifetime, val *T, optFuncs ...ResolutionOp
 
 // RegisterInstanceToRegistry registers a pre-initialized instance with a specified lifetime to the registry.
 // Returns an error if the instance is invalid or not supported by the given lifetime.
+// With the Prototype lifetime, the instance is a template copied on every resolution, see Cloner and WithDeepCopy.
 //
 // The optFuncs parameter allows for optional configuration of the registration, such as setting a scope or thread ID.
 //
@@ -153,6 +199,7 @@ func RegisterInstance[T any](lifetime Lifetime, val *T, optFuncs ...ResolutionOp
 //   - WithScope(scope string): Sets a scope for scoped instances. Required when lifetime is Scoped.
 //   - WithThreadID(threadID string): Sets a thread ID for thread-local instances. Optional and defaults
 //     to the current goroutine ID if not provided and the lifetime is ThreadLocal.
+//   - WithDeepCopy(): Deeply copies the template of a prototype.
 //
 // Example:
 //
@@ -185,6 +232,10 @@ func RegisterInstanceToRegistry[T any](reg *Registry, lifetime Lifetime, val *T,
 		return ErrTransientInstance
 	}
 
+	if lifetime == Pooled {
+		return ErrPooledInstance
+	}
+
 	opt := newResolutionOptions(optFns...)
 
 	if lifetime == Scoped && opt.scope == "" {
@@ -195,14 +246,9 @@ func RegisterInstanceToRegistry[T any](reg *Registry, lifetime Lifetime, val *T,
 		opt.threadID = internal.GetGoroutineID()
 	}
 
-	_, name, err := ensureRegistrable[T](reg, lifetime, opt)
-	if err != nil {
-		return err
-	}
-
-	reg.set(name, lifetime, reflect.ValueOf(val), opt)
+	entry := serviceEntry{typ: reflect.TypeFor[T](), lifetime: lifetime, instance: true} //nolint:exhaustruct
 
-	return nil
+	return reg.register(entry, reflect.ValueOf(val), opt)
 }
 
 // RegisterSingletonInstance registers pre-initialized singleton instance to the global registry.
@@ -308,23 +354,62 @@ func RegisterThreadLocalInstanceToRegistry[T any](registry *Registry, val *T, op
 	return RegisterInstanceToRegistry(registry, ThreadLocal, val, optFuncs...)
 }
 
-// ensureRegistrable checks if a type is registrable and not already registered in the registry.
-// Returns the type, name, and an error if the type is not registrable or already registered.
-func ensureRegistrable[T any](reg *Registry, lifetime Lifetime, opt *ResolutionOptions) (reflect.Type, string, error) {
-	typ := reflect.TypeFor[T]()
-	name := internal.ServiceName(typ)
+// ensureRegistrable checks if a type is registrable with the lifetime and not already registered in the registry.
+// Returns the key under which the instance is held, or an error if the type is not registrable or already
+// registered.
+func ensureRegistrable(
+	reg *Registry,
+	typ reflect.Type,
+	lifetime Lifetime,
+	value reflect.Value,
+	opt *ResolutionOptions,
+) (string, error) {
+	if typ == nil {
+		return "", ErrInvalidServiceType
+	}
+
+	if reg.err != nil {
+		return "", reg.err
+	}
+
+	strategy, known := reg.strategies[lifetime]
+	if !known {
+		return "", fmt.Errorf("%w: %s", ErrInvalidLifetime, lifetime)
+	}
+
+	if lifetime == Prototype && (!value.IsValid() || value.IsNil()) {
+		return "", ErrPrototypeTemplate
+	}
 
-	if !internal.IsStructType(typ) {
-		return nil, "", fmt.Errorf("%w: %s", ErrInvalidServiceType, name)
+	retention := reg.retentions[lifetime]
+	if retention == RetainNone && value.IsValid() && lifetime != Prototype {
+		return "", ErrTransientInstance
 	}
 
-	entry, exists := reg.has(name)
-	if exists && entry.lifetime == lifetime && (lifetime == Transient ||
-		lifetime == Singleton ||
-		(lifetime == Scoped && reg.hasScoped(opt.scope, name)) ||
-		(lifetime == ThreadLocal && reg.hasThreadLocal(opt.threadID, name))) {
-		return nil, "", fmt.Errorf("%w: %s", ErrRegistered, name)
+	if (opt.ttl > 0 || opt.refresh > 0) && (lifetime != Singleton || value.IsValid()) {
+		return "", fmt.Errorf("%w: %s", ErrRefresh, lifetime)
+	}
+
+	var key string
+
+	if retention == RetainRegistered || value.IsValid() {
+		keys, err := strategy.Keys(opt)
+		if err != nil {
+			return "", err
+		}
+
+		if len(keys) > 0 {
+			key = keys[0]
+		}
+	}
+
+	reg.lock.RLock()
+	defer reg.lock.RUnlock()
+
+	entry, exists := reg.registeredServices[typ]
+	if exists && entry.lifetime == lifetime && (retention != RetainRegistered || reg.holds(lifetime, key, typ)) {
+		return "", fmt.Errorf("%w: %s", ErrRegistered, entry.name)
 	}
 
-	return typ, name, nil
+	return key, nil
 }
@@ -1,6 +1,7 @@
 package needle_test
 
 import (
+	"reflect"
 	"testing"
 
 	"github.com/goplexhq/needle"
@@ -11,7 +12,7 @@ import (
 func TestNeedle_Register_InvalidType(t *testing.T) {
 	t.Cleanup(needle.Reset)
 
-	regErr := needle.Register[int](needle.Transient)
+	regErr := needle.Register[error](needle.Transient)
 	assert.ErrorIs(t, regErr, needle.ErrInvalidServiceType)
 }
 
@@ -36,6 +37,34 @@ func TestNeedle_Register(t *testing.T) {
 	assert.Contains(t, services, "github.com/goplexhq/needle_test.testStruct")
 }
 
+func TestNeedle_RegisterToRegistry_InjectsOnResolution(t *testing.T) {
+	type Dependency struct{}
+
+	type Service struct {
+		Dependency *Dependency `needle:"inject"`
+	}
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[Service](registry, needle.Singleton))
+
+	_, err := needle.ResolveFromRegistry[Service](registry)
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+
+	require.NoError(t, needle.RegisterToRegistry[Dependency](registry, needle.Singleton))
+
+	service, err := needle.ResolveFromRegistry[Service](registry)
+	require.NoError(t, err)
+	assert.NotNil(t, service.Dependency)
+
+	// a zero value registered as an instance is not injected
+	plain := needle.NewRegistry()
+	require.NoError(t, needle.RegisterInstanceToRegistry(plain, needle.Singleton, &Service{}))
+
+	service, err = needle.ResolveFromRegistry[Service](plain)
+	require.NoError(t, err)
+	assert.Nil(t, service.Dependency)
+}
+
 func TestNeedle_RegisterSingletonInstance(t *testing.T) {
 	t.Cleanup(needle.Reset)
 
@@ -134,3 +163,96 @@ func TestNeedle_RegisterThreadLocal_CustomThreadID(t *testing.T) {
 	assert.Len(t, services, 1)
 	assert.Contains(t, services, "github.com/goplexhq/needle_test.testStruct")
 }
+
+func TestNeedle_RegisterType(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{}
+
+	require.NoError(t, needle.RegisterType(reflect.TypeFor[testStruct](), needle.Singleton))
+	require.ErrorIs(t, needle.RegisterType(reflect.TypeFor[testStruct](), needle.Singleton), needle.ErrRegistered)
+	require.ErrorIs(t, needle.RegisterType(reflect.TypeFor[error](), needle.Singleton), needle.ErrInvalidServiceType)
+	require.ErrorIs(t, needle.RegisterType(nil, needle.Singleton), needle.ErrInvalidServiceType)
+
+	services := needle.RegisteredServices()
+	assert.Len(t, services, 1)
+	assert.Contains(t, services, "github.com/goplexhq/needle_test.testStruct")
+}
+
+func TestNeedle_RegisterTypeToRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{}
+
+	registry := needle.NewRegistry()
+
+	err := needle.RegisterTypeToRegistry(registry, reflect.TypeFor[testStruct](), needle.Scoped)
+	require.ErrorIs(t, err, needle.ErrEmptyScope)
+
+	err = needle.RegisterTypeToRegistry(registry, reflect.TypeFor[testStruct](), needle.Scoped, needle.WithScope("scope"))
+	require.NoError(t, err)
+
+	services := registry.RegisteredServices()
+	assert.Len(t, services, 1)
+	assert.Contains(t, services, "github.com/goplexhq/needle_test.testStruct")
+}
+
+type testNeedleRegisterRepo[T any] struct{ items []T }
+
+func TestNeedle_RegisterGenericTypes(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type user struct{}
+
+	type order struct{}
+
+	require.NoError(t, needle.RegisterSingletonInstance(&testNeedleRegisterRepo[user]{items: []user{{}}}))
+	require.NoError(t, needle.RegisterSingletonInstance(&testNeedleRegisterRepo[order]{items: nil}))
+
+	users, err := needle.Resolve[testNeedleRegisterRepo[user]]()
+	require.NoError(t, err)
+	assert.Len(t, users.items, 1)
+
+	orders, err := needle.Resolve[testNeedleRegisterRepo[order]]()
+	require.NoError(t, err)
+	assert.Empty(t, orders.items)
+
+	assert.Len(t, needle.RegisteredServices(), 2)
+}
+
+func TestNeedle_RegisterNonStructTypes(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{ name string }
+
+	instance := &testStruct{name: "myStruct"}
+	names := []string{"a", "b"}
+	greet := func(name string) string { return "Hello, " + name }
+
+	require.NoError(t, needle.RegisterSingletonInstance(&instance))
+	require.NoError(t, needle.RegisterSingletonInstance(&names))
+	require.NoError(t, needle.RegisterSingletonInstance(&greet))
+	require.NoError(t, needle.Register[int](needle.Transient))
+
+	ptr, err := needle.Resolve[*testStruct]()
+	require.NoError(t, err)
+	assert.Same(t, instance, *ptr)
+
+	slice, err := needle.Resolve[[]string]()
+	require.NoError(t, err)
+	assert.Equal(t, names, *slice)
+
+	fn, err := needle.Resolve[func(string) string]()
+	require.NoError(t, err)
+	assert.Equal(t, "Hello, Needle", (*fn)("Needle"))
+
+	num, err := needle.Resolve[int]()
+	require.NoError(t, err)
+	assert.Equal(t, 0, *num)
+
+	services := needle.RegisteredServices()
+	assert.Contains(t, services, "*github.com/goplexhq/needle_test.testStruct")
+	assert.Contains(t, services, "[]string")
+	assert.Contains(t, services, "func(string) string")
+	assert.Contains(t, services, "int")
+}
@@ -1,143 +1,460 @@
 package needle
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"reflect"
+	"slices"
 	"sync"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
 )
 
 // Registry represents a thread-safe registry for storing service instances and their metadata.
+//
+// Services are identified by their reflect.Type, which allows registering any type, including pointers,
+// slices, funcs and distinct instantiations of generic types. Service names are used for display only.
 type Registry struct {
-	registeredServices  map[string]serviceEntry
-	transientServices   map[string]reflect.Value
-	scopedServices      map[string]map[string]reflect.Value
-	threadLocalServices map[string]map[string]reflect.Value
-	singletonServices   map[string]reflect.Value
-	lock                sync.RWMutex
+	registeredServices map[reflect.Type]serviceEntry
+	instances          map[Lifetime]map[string]map[reflect.Type]serviceInstance // held instances, by lifetime and key.
+	strategies         map[Lifetime]LifetimeStrategy
+	retentions         map[Lifetime]Retention              // retentions of the strategies, queried once.
+	scopeParents       map[string]string                   // parents of the opened scopes, empty for root scopes.
+	refreshers         map[reflect.Type]context.CancelFunc // background refreshes of singletons, by type.
+	retired            map[*time.Timer]heldInstance        // expired or refreshed instances waiting to be disposed.
+	flights            map[flightKey]*flight               // creations of retained instances in progress.
+	observers          []Observer
+	tracer             Tracer
+	strict             bool
+	retry              retryPolicy
+	err                error // error of the options the registry was created with, failing registrations and builds.
+	lock               sync.RWMutex
 }
 
 // NewRegistry creates and returns a new instance of Registry.
-func NewRegistry() *Registry {
-	return &Registry{ //nolint:exhaustruct
-		registeredServices:  make(map[string]serviceEntry),
-		transientServices:   make(map[string]reflect.Value),
-		scopedServices:      make(map[string]map[string]reflect.Value),
-		threadLocalServices: make(map[string]map[string]reflect.Value),
-		singletonServices:   make(map[string]reflect.Value),
+//
+// The optFuncs parameter allows for optional configuration of the registry.
+//
+// Available options:
+//   - WithObserver(observer Observer): Adds an observer receiving the events of the registry.
+//   - WithLogger(logger *slog.Logger): Sets the logger of the registry.
+//   - WithSlowThreshold(threshold time.Duration): Sets the duration above which a service creation is logged as slow.
+//   - WithTracer(tracer Tracer): Sets the tracer starting spans around resolutions and factory calls.
+//   - WithStrictLifetimes(): Makes resolutions of captive dependencies fail.
+//   - WithLifetime(lifetime Lifetime, strategy LifetimeStrategy): Adds a custom lifetime.
+//   - WithFactoryRetry(attempts int, minBackoff, maxBackoff time.Duration): Retries failed factory calls.
+//
+// Example:
+//
+//	registry := needle.NewRegistry(needle.WithObserver(&MyObserver{}))
+func NewRegistry(optFuncs ...RegistryOptionFunc) *Registry {
+	opt := newRegistryOptions(optFuncs...)
+
+	registry := &Registry{ //nolint:exhaustruct
+		registeredServices: make(map[reflect.Type]serviceEntry),
+		instances:          make(map[Lifetime]map[string]map[reflect.Type]serviceInstance),
+		scopeParents:       make(map[string]string),
+		refreshers:         make(map[reflect.Type]context.CancelFunc),
+		retired:            make(map[*time.Timer]heldInstance),
+		flights:            make(map[flightKey]*flight),
+		observers:          opt.observers,
+		tracer:             opt.tracer,
+		strict:             opt.strict,
+		retry:              opt.retry,
 	}
+
+	registry.strategies = builtinStrategies(registry)
+
+	var errs []error
+
+	for _, lifetime := range sortedLifetimes(opt.lifetimes) {
+		switch {
+		case lifetime.Valid():
+			errs = append(errs, fmt.Errorf("%w: %s", ErrBuiltinLifetime, lifetime))
+		case opt.lifetimes[lifetime] == nil:
+			errs = append(errs, fmt.Errorf("%w: %s", ErrNilStrategy, lifetime))
+		default:
+			registry.strategies[lifetime] = opt.lifetimes[lifetime]
+		}
+	}
+
+	registry.retentions = make(map[Lifetime]Retention, len(registry.strategies))
+	for lifetime, strategy := range registry.strategies {
+		registry.retentions[lifetime] = strategy.Retention()
+	}
+
+	registry.err = errors.Join(errs...)
+
+	return registry
 }
 
-// set adds or updates a service entry in the registry.
-func (r *Registry) set(name string, lifetime Lifetime, value reflect.Value, options *ResolutionOptions) {
+// register adds a service entry to the registry unless it is already registered, and notifies the observers.
+// An invalid value registers an instance that is created on first resolution.
+func (r *Registry) register(entry serviceEntry, value reflect.Value, options *ResolutionOptions) error {
+	entry.ttl = options.ttl
+	entry.refresh = options.refresh
+	entry.deepCopy = options.deepCopy
+
+	if entry.lifetime == Prototype {
+		entry.template = value
+	}
+
+	key, err := ensureRegistrable(r, entry.typ, entry.lifetime, value, options)
+	if err != nil {
+		if entry.typ != nil {
+			r.observeRegister(entry, options, err)
+		}
+
+		return err
+	}
+
+	scopeCreated, previous := r.set(entry, value, key)
+	if previous.typ != nil && previous.lifetime != entry.lifetime {
+		r.release(removal{instances: nil, scopes: nil, services: []serviceEntry{previous}})
+	}
+
+	r.observeRegister(entry, options, nil)
+
+	if scopeCreated {
+		r.observeScopes([]string{key}, nil)
+	}
+
+	return nil
+}
+
+// set adds or updates a service entry in the registry, holding the given value under the key when the strategy
+// of the entry's lifetime retains instances. An invalid value registers an instance that is created on first
+// resolution. Returns true when the entry is Scoped and its scope was created, along with the entry it updated,
+// if any.
+func (r *Registry) set(entry serviceEntry, value reflect.Value, key string) (bool, serviceEntry) {
 	r.lock.Lock()
 	defer r.lock.Unlock()
 
-	r.registeredServices[name] = serviceEntry{
-		name:     name,
-		lifetime: lifetime,
-		value:    nil,
+	previous := r.registeredServices[entry.typ]
+
+	entry.name = internal.ServiceName(entry.typ)
+	entry.value = nil
+	r.registeredServices[entry.typ] = entry
+
+	retention := r.retentions[entry.lifetime]
+	if retention == RetainNone || (retention == RetainPerKey && !value.IsValid()) {
+		return false, previous
 	}
 
-	switch lifetime {
-	case Transient:
-		r.transientServices[name] = value
-	case Scoped:
-		if r.scopedServices[options.scope] == nil {
-			r.scopedServices[options.scope] = make(map[string]reflect.Value)
-		}
+	scopeCreated := entry.lifetime == Scoped && !r.hasScope(key)
 
-		r.scopedServices[options.scope][name] = value
-	case ThreadLocal:
-		if r.threadLocalServices[options.threadID] == nil {
-			r.threadLocalServices[options.threadID] = make(map[string]reflect.Value)
-		}
+	inst := serviceInstance{value: value, createdAt: time.Time{}}
+	if value.IsValid() {
+		inst.createdAt = time.Now()
+	}
+
+	r.hold(entry.lifetime, key, entry.typ, inst)
+
+	return scopeCreated, previous
+}
 
-		r.threadLocalServices[options.threadID][name] = value
-	case Singleton:
-		r.singletonServices[name] = value
+// hold stores an instance of a type under a key of a lifetime.
+// The caller must hold the registry lock.
+func (r *Registry) hold(lifetime Lifetime, key string, typ reflect.Type, inst serviceInstance) {
+	if r.instances[lifetime] == nil {
+		r.instances[lifetime] = make(map[string]map[reflect.Type]serviceInstance)
 	}
+
+	if r.instances[lifetime][key] == nil {
+		r.instances[lifetime][key] = make(map[reflect.Type]serviceInstance)
+	}
+
+	r.instances[lifetime][key][typ] = inst
 }
 
-// get retrieves a service entry from the registry by name.
-// Returns the entry and a boolean indicating whether the entry was found.
-func (r *Registry) get(name string, options *ResolutionOptions) (serviceEntry, bool) {
+// holds reports whether an instance of a type, created or not, is held under a key of a lifetime.
+// The caller must hold the registry lock.
+func (r *Registry) holds(lifetime Lifetime, key string, typ reflect.Type) bool {
+	_, found := r.instances[lifetime][key][typ]
+
+	return found
+}
+
+// store records a newly created instance of a lazily created service under the key, along with the expired
+// instance it replaces, if any.
+// When another instance was stored concurrently, or the service is no longer registered, the stored value is
+// kept and returned along with false.
+func (r *Registry) store(entry serviceEntry, key string, value reflect.Value) (reflect.Value, bool, heldInstance) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var expired heldInstance
+
+	retention := r.retentions[entry.lifetime]
+	if retention == RetainNone {
+		return value, false, expired
+	}
+
+	current, registered := r.registeredServices[entry.typ]
+	if !registered || current.lifetime != entry.lifetime {
+		return value, false, expired
+	}
+
+	inst, exists := r.instances[entry.lifetime][key][entry.typ]
+	if !exists && retention == RetainRegistered {
+		return value, false, expired
+	}
+
+	if current.expired(inst) {
+		expired = newHeldInstance(entry.typ, entry.lifetime, key, inst.value)
+	} else if inst.value.IsValid() {
+		return inst.value, false, expired
+	}
+
+	r.hold(entry.lifetime, key, entry.typ, serviceInstance{value: value, createdAt: time.Now()})
+
+	return value, true, expired
+}
+
+// get retrieves a service entry from the registry by type, along with the instance held under the first of the
+// given keys holding one.
+// Returns the entry, the key under which its instance is held or created, and a boolean indicating whether the
+// entry was found. The value of the entry is invalid when an instance has to be created.
+func (r *Registry) get(typ reflect.Type, keys []string) (serviceEntry, string, bool) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
-	entry, found := r.registeredServices[name]
+	entry, found := r.registeredServices[typ]
 	if !found {
-		return entry, false
+		return entry, "", false
 	}
 
-	var (
-		value  reflect.Value
-		exists bool
-	)
-
-	switch entry.lifetime {
-	case Transient:
-		value, exists = r.transientServices[name]
-	case Scoped:
-		scope, scopeFound := r.scopedServices[options.scope]
-		if !scopeFound {
-			return entry, false
-		}
+	for _, key := range keys {
+		if inst, exists := r.instances[entry.lifetime][key][typ]; exists {
+			if entry.expired(inst) {
+				return entry.withValue(&reflect.Value{}), key, true
+			}
 
-		value, exists = scope[name]
-	case ThreadLocal:
-		thread, threadFound := r.threadLocalServices[options.threadID]
-		if !threadFound {
-			return entry, false
+			return entry.withValue(&inst.value), key, true
 		}
+	}
 
-		value, exists = thread[name]
-	case Singleton:
-		value, exists = r.singletonServices[name]
+	if r.retentions[entry.lifetime] == RetainRegistered {
+		return entry, "", false
 	}
 
-	if !exists {
-		return entry, false
+	var (
+		inst serviceInstance
+		key  string
+	)
+
+	if len(keys) > 0 {
+		key = keys[0]
 	}
 
-	return entry.withValue(&value), true
+	return entry.withValue(&inst.value), key, true
 }
 
-// has checks if a service entry exists in the registry by name.
-func (r *Registry) has(name string) (serviceEntry, bool) {
+// has checks if a service entry exists in the registry by type.
+func (r *Registry) has(typ reflect.Type) (serviceEntry, bool) {
 	r.lock.RLock()
-	entry, found := r.registeredServices[name]
+	entry, found := r.registeredServices[typ]
 	r.lock.RUnlock()
 
 	return entry, found
 }
 
-// hasScoped checks if a scoped service entry exists in the registry by scope and name.
-func (r *Registry) hasScoped(scope, name string) bool {
+// instanceKey returns the key under which the instance of a registered service is held for the given options.
+// Returns an empty key when the service is not registered or its lifetime does not key instances.
+func (r *Registry) instanceKey(typ reflect.Type, options *ResolutionOptions) (string, error) {
+	entry, found := r.has(typ)
+	if !found {
+		return "", nil
+	}
+
+	keys, err := r.strategies[entry.lifetime].Keys(options)
+	if err != nil || len(keys) == 0 {
+		return "", err
+	}
+
+	return keys[0], nil
+}
+
+// lookup finds the type of a registered service by its name. Returns ErrNotRegistered if no service has the name,
+// or ErrAmbiguousName if several do, such as types of the same name declared in different functions.
+func (r *Registry) lookup(name string) (reflect.Type, error) {
 	r.lock.RLock()
 	defer r.lock.RUnlock()
 
-	s, scopeExists := r.scopedServices[scope]
-	if !scopeExists {
-		return false
+	var found reflect.Type
+
+	for typ, entry := range r.registeredServices {
+		if entry.name != name {
+			continue
+		}
+
+		if found != nil {
+			return nil, ErrAmbiguousName
+		}
+
+		found = typ
 	}
 
-	_, found := s[name]
+	if found == nil {
+		return nil, ErrNotRegistered
+	}
 
-	return found
+	return found, nil
 }
 
-// hasThreadLocal checks if a thread-local service entry exists in the registry by thread ID and name.
-func (r *Registry) hasThreadLocal(thread, name string) bool {
-	r.lock.RLock()
-	defer r.lock.RUnlock()
+// remove removes a service from the registry and returns the removed instances, the closed scopes, and the
+// registration if removed.
+//
+// When the service is Scoped and a scope is given, or ThreadLocal and a thread ID is given, only the instance
+// held by that scope or thread is removed, and the registration is removed once no instances remain.
+// Otherwise, the registration and all of its instances are removed.
+func (r *Registry) remove(typ reflect.Type, options *ResolutionOptions) (removal, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
 
-	t, threadExists := r.threadLocalServices[thread]
-	if !threadExists {
-		return false
+	var removed removal
+
+	entry, found := r.registeredServices[typ]
+	if !found {
+		return removed, false
 	}
 
-	_, found := t[name]
+	r.cancelRefresh(typ)
 
-	return found
+	removeKey := func(lifetime Lifetime, key string) {
+		if inst, exists := removeInstance(r.instances[lifetime], key, typ); exists {
+			removed.instances = append(removed.instances, newHeldInstance(typ, lifetime, key, inst))
+
+			if lifetime == Scoped && !r.hasScope(key) {
+				removed.scopes = append(removed.scopes, key)
+			}
+		}
+	}
+
+	if key := optionKey(entry.lifetime, options); key != "" {
+		removeKey(entry.lifetime, key)
+
+		if !r.holdsInstances(typ) {
+			removed.services = append(removed.services, entry)
+			delete(r.registeredServices, typ)
+		}
+
+		return removed, true
+	}
+
+	for _, lifetime := range sortedLifetimes(r.instances) {
+		for key := range r.instances[lifetime] {
+			removeKey(lifetime, key)
+		}
+	}
+
+	removed.services = append(removed.services, entry)
+	delete(r.registeredServices, typ)
+
+	return removed, true
+}
+
+// optionKey returns the key given in the options to remove a single instance of a service: the scope of a Scoped
+// service, or the thread ID of a ThreadLocal service.
+func optionKey(lifetime Lifetime, options *ResolutionOptions) string {
+	switch lifetime {
+	case Scoped:
+		return options.scope
+	case ThreadLocal:
+		return options.threadID
+	default:
+		return ""
+	}
+}
+
+// removeInstance removes the instance of a type held under a key and returns it.
+// The key is dropped once it holds no instances.
+func removeInstance(
+	services map[string]map[reflect.Type]serviceInstance,
+	key string,
+	typ reflect.Type,
+) (reflect.Value, bool) {
+	inst, exists := services[key][typ]
+	if !exists {
+		return reflect.Value{}, false
+	}
+
+	delete(services[key], typ)
+
+	if len(services[key]) == 0 {
+		delete(services, key)
+	}
+
+	return inst.value, true
+}
+
+// holdsInstances reports whether any key of a lifetime retaining registered instances holds an instance of the
+// type.
+// The caller must hold the registry lock.
+func (r *Registry) holdsInstances(typ reflect.Type) bool {
+	for lifetime, keys := range r.instances {
+		if r.retentions[lifetime] != RetainRegistered {
+			continue
+		}
+
+		for _, services := range keys {
+			if _, found := services[typ]; found {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// replace swaps the instance of a registered service held under the key and returns the previous instance.
+// Returns an error if the service, or its instance for the key, is not registered.
+func (r *Registry) replace(typ reflect.Type, value reflect.Value, key string) (heldInstance, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	name := internal.ServiceName(typ)
+
+	entry, found := r.registeredServices[typ]
+	if !found {
+		return heldInstance{}, fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	}
+
+	if entry.lifetime == Prototype {
+		if value.IsNil() {
+			return heldInstance{}, fmt.Errorf("%w: %s", ErrPrototypeTemplate, name)
+		}
+
+		entry.template = value
+		r.registeredServices[typ] = entry
+
+		return heldInstance{}, nil
+	}
+
+	retention := r.retentions[entry.lifetime]
+
+	switch {
+	case retention == RetainNone && entry.lifetime == Pooled:
+		return heldInstance{}, ErrPooledInstance
+	case retention == RetainNone:
+		return heldInstance{}, ErrTransientInstance
+	case retention == RetainRegistered && !r.holds(entry.lifetime, key, typ):
+		return heldInstance{}, fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	}
+
+	old := r.instances[entry.lifetime][key][typ]
+
+	r.hold(entry.lifetime, key, typ, serviceInstance{value: value, createdAt: time.Now()})
+	r.cancelRefresh(typ)
+
+	entry.instance = true
+	entry.ttl = 0
+	entry.refresh = 0
+	r.registeredServices[typ] = entry
+
+	return newHeldInstance(typ, entry.lifetime, key, old.value), nil
 }
 
 // RegisteredServices returns a list of names of all registered services.
@@ -147,8 +464,8 @@ func (r *Registry) RegisteredServices() []string {
 	defer r.lock.RUnlock()
 
 	names := make([]string, 0, len(r.registeredServices))
-	for name := range r.registeredServices {
-		names = append(names, name)
+	for _, entry := range r.registeredServices {
+		names = append(names, entry.name)
 	}
 
 	return names
@@ -157,11 +474,50 @@ func (r *Registry) RegisteredServices() []string {
 // Reset clears all entries in the registry.
 func (r *Registry) Reset() {
 	r.lock.Lock()
-	defer r.lock.Unlock()
+	removed := r.clear()
+	r.lock.Unlock()
+
+	r.release(removed)
+}
+
+// clear clears all entries in the registry and returns the closed scopes, sorted, and the removed registrations.
+// The caller must hold the registry lock.
+func (r *Registry) clear() removal {
+	scopes := make([]string, 0, len(r.instances[Scoped])+len(r.scopeParents))
+	for scope := range r.instances[Scoped] {
+		scopes = append(scopes, scope)
+	}
+
+	for scope := range r.scopeParents {
+		if _, held := r.instances[Scoped][scope]; !held {
+			scopes = append(scopes, scope)
+		}
+	}
+
+	slices.Sort(scopes)
+
+	r.stopRefreshes()
+
+	services := make([]serviceEntry, 0, len(r.registeredServices))
+	for _, typ := range sortedTypes(r.registeredServices) {
+		services = append(services, r.registeredServices[typ])
+	}
 
 	clear(r.registeredServices)
-	clear(r.transientServices)
-	clear(r.scopedServices)
-	clear(r.threadLocalServices)
-	clear(r.singletonServices)
+	clear(r.instances)
+	clear(r.scopeParents)
+
+	return removal{instances: nil, scopes: scopes, services: services}
+}
+
+// sortedLifetimes returns the lifetimes of the given map, sorted.
+func sortedLifetimes[V any](lifetimes map[Lifetime]V) []Lifetime {
+	sorted := make([]Lifetime, 0, len(lifetimes))
+	for lifetime := range lifetimes {
+		sorted = append(sorted, lifetime)
+	}
+
+	slices.Sort(sorted)
+
+	return sorted
 }
@@ -1,9 +1,22 @@
 package needle
 
+import (
+	"context"
+	"reflect"
+	"slices"
+	"time"
+)
+
 // ResolutionOptions holds configuration options for resolving services.
 type ResolutionOptions struct {
 	scope    string
 	threadID string
+	ctx      context.Context //nolint:containedctx // carries the span of the dependent being resolved.
+	chain    []reflect.Type  // dependents of the service being resolved, outermost first.
+	ttl      time.Duration   // time to live of a registered singleton.
+	refresh  time.Duration   // refresh interval of a registered singleton.
+	deepCopy bool            // whether the template of a registered prototype is deeply copied.
+	flight   *flight         // creation of the closest retained dependent being resolved, nil if none.
 }
 
 // ResolutionOptionFunc is a function that modifies a ResolutionOptions struct.
@@ -31,6 +44,37 @@ func WithThreadID(id string) ResolutionOptionFunc {
 	}
 }
 
+// WithContext sets the context of a resolution, such as the context of the request being served. The spans of
+// the resolution are started in the context, and lifetime strategies may key instances by its values.
+//
+// Example:
+//
+//	handler, err := needle.Resolve[Handler](needle.WithScope(requestID), needle.WithContext(r.Context()))
+func WithContext(ctx context.Context) ResolutionOptionFunc {
+	return func(o *ResolutionOptions) {
+		o.ctx = ctx
+	}
+}
+
+// Scope returns the scope of the resolution, empty if not set.
+func (o *ResolutionOptions) Scope() string {
+	return o.scope
+}
+
+// ThreadID returns the thread ID of the resolution, empty if not set.
+func (o *ResolutionOptions) ThreadID() string {
+	return o.threadID
+}
+
+// Context returns the context of the resolution, defaulting to context.Background().
+func (o *ResolutionOptions) Context() context.Context {
+	if o.ctx == nil {
+		return context.Background()
+	}
+
+	return o.ctx
+}
+
 // newResolutionOptions creates a new ResolutionOptions struct from the provided option functions.
 //
 // Example:
@@ -44,3 +88,35 @@ func newResolutionOptions(optFuncs ...ResolutionOptionFunc) *ResolutionOptions {
 
 	return opt
 }
+
+// withDependent returns a copy of the options with the given type appended to the chain of dependents.
+func (o *ResolutionOptions) withDependent(typ reflect.Type) *ResolutionOptions {
+	opt := *o
+	opt.chain = append(slices.Clip(o.chain), typ)
+
+	return &opt
+}
+
+// withScope returns a copy of the options with the given scope.
+func (o *ResolutionOptions) withScope(scope string) *ResolutionOptions {
+	opt := *o
+	opt.scope = scope
+
+	return &opt
+}
+
+// withFlight returns a copy of the options with the given creation of a retained instance.
+func (o *ResolutionOptions) withFlight(created *flight) *ResolutionOptions {
+	opt := *o
+	opt.flight = created
+
+	return &opt
+}
+
+// withContext returns a copy of the options with the given context.
+func (o *ResolutionOptions) withContext(ctx context.Context) *ResolutionOptions {
+	opt := *o
+	opt.ctx = ctx
+
+	return &opt
+}
@@ -0,0 +1,205 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// maxSuggestions limits the number of suggestions attached to a ResolutionError.
+const maxSuggestions = 5
+
+// ResolutionError describes a failure to resolve a service from the registry.
+//
+// It carries the requested type, the chain of dependents that led to the request, the lifetime, scope and thread
+// involved, and suggestions of what may have been meant instead. The underlying cause, such as ErrNotRegistered or
+// ErrEmptyScope, is available through errors.Is.
+//
+// Example:
+//
+//	var resErr *needle.ResolutionError
+//	if errors.As(err, &resErr) {
+//	    fmt.Println(resErr.Name, resErr.Chain, resErr.Suggestions)
+//	}
+type ResolutionError struct {
+	Name        string         // Display name of the requested service.
+	Type        reflect.Type   // Requested type, nil when resolved by an unknown name.
+	Chain       []reflect.Type // Dependents that led to the request, outermost first.
+	Lifetime    Lifetime       // Lifetime of the registered service, empty when not registered.
+	Scope       string         // Scope used for the resolution.
+	ThreadID    string         // Thread ID used for the resolution.
+	Suggestions []string       // Registered alternatives, e.g. other scopes or similarly named types.
+	Err         error          // Underlying cause.
+}
+
+// Error returns a description of the failure including the dependency chain and suggestions.
+func (e *ResolutionError) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(e.Err.Error())
+	builder.WriteString(": ")
+	builder.WriteString(e.Name)
+
+	if len(e.Chain) > 0 {
+		names := make([]string, len(e.Chain))
+		for idx, typ := range e.Chain {
+			names[idx] = internal.ServiceName(typ)
+		}
+
+		builder.WriteString(" (required by ")
+		builder.WriteString(strings.Join(names, " -> "))
+		builder.WriteString(")")
+	}
+
+	switch {
+	case e.Lifetime == Scoped && e.Scope != "":
+		fmt.Fprintf(&builder, " [%s in scope %q]", e.Lifetime, e.Scope)
+	case e.Lifetime == ThreadLocal && e.ThreadID != "":
+		fmt.Fprintf(&builder, " [%s in thread %q]", e.Lifetime, e.ThreadID)
+	case e.Lifetime != "":
+		fmt.Fprintf(&builder, " [%s]", e.Lifetime)
+	}
+
+	if len(e.Suggestions) > 0 {
+		builder.WriteString("; did you mean ")
+		builder.WriteString(strings.Join(e.Suggestions, " or "))
+		builder.WriteString("?")
+	}
+
+	return builder.String()
+}
+
+// Unwrap returns the underlying cause of the failure.
+func (e *ResolutionError) Unwrap() error {
+	return e.Err
+}
+
+// newResolutionError creates a ResolutionError for the given type, cause and resolution options,
+// and populates its suggestions from the registry.
+func newResolutionError(
+	registry *Registry,
+	typ reflect.Type,
+	lifetime Lifetime,
+	opt *ResolutionOptions,
+	err error,
+) *ResolutionError {
+	resErr := &ResolutionError{
+		Name:        internal.ServiceName(typ),
+		Type:        typ,
+		Chain:       slices.Clone(opt.chain),
+		Lifetime:    lifetime,
+		Scope:       opt.scope,
+		ThreadID:    opt.threadID,
+		Suggestions: nil,
+		Err:         err,
+	}
+
+	switch lifetime {
+	case Scoped:
+		for _, scope := range registry.keysOf(Scoped, typ) {
+			resErr.Suggestions = append(resErr.Suggestions, fmt.Sprintf("scope %q", scope))
+		}
+	case ThreadLocal:
+		for _, thread := range registry.keysOf(ThreadLocal, typ) {
+			resErr.Suggestions = append(resErr.Suggestions, fmt.Sprintf("thread %q", thread))
+		}
+	case "":
+		for _, similar := range registry.similarTo(typ) {
+			resErr.Suggestions = append(resErr.Suggestions, internal.ServiceName(similar))
+		}
+	}
+
+	if len(resErr.Suggestions) > maxSuggestions {
+		resErr.Suggestions = resErr.Suggestions[:maxSuggestions]
+	}
+
+	return resErr
+}
+
+// keysOf returns the sorted keys of a lifetime holding an instance of the given type, such as scopes.
+func (r *Registry) keysOf(lifetime Lifetime, typ reflect.Type) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var keys []string
+
+	for key, services := range r.instances[lifetime] {
+		if _, found := services[typ]; found {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+// similarTo returns registered types that may have been meant instead of the given type, sorted by name:
+// the pointer or element type of the given type, and types with the same name in other packages.
+func (r *Registry) similarTo(typ reflect.Type) []reflect.Type {
+	if typ == nil {
+		return nil
+	}
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var similar []reflect.Type
+
+	for registered := range r.registeredServices {
+		if registered == typ {
+			continue
+		}
+
+		if isPointerTo(registered, typ) || isPointerTo(typ, registered) ||
+			(baseName(registered) != "" && strings.EqualFold(baseName(registered), baseName(typ))) {
+			similar = append(similar, registered)
+		}
+	}
+
+	slices.SortFunc(similar, func(a, b reflect.Type) int {
+		return strings.Compare(internal.ServiceName(a), internal.ServiceName(b))
+	})
+
+	return similar
+}
+
+// similarNames returns the sorted names of registered services with the same unqualified name as the given name.
+func (r *Registry) similarNames(name string) []string {
+	base, _, _ := strings.Cut(name, "[")
+	base = base[strings.LastIndex(base, ".")+1:]
+
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	var names []string
+
+	for registered, entry := range r.registeredServices {
+		if baseName(registered) != "" && strings.EqualFold(baseName(registered), base) {
+			names = append(names, entry.name)
+		}
+	}
+
+	slices.Sort(names)
+
+	if len(names) > maxSuggestions {
+		names = names[:maxSuggestions]
+	}
+
+	return names
+}
+
+// isPointerTo reports whether ptr is a pointer type to elem.
+func isPointerTo(ptr, elem reflect.Type) bool {
+	return ptr.Kind() == reflect.Ptr && ptr.Elem() == elem
+}
+
+// baseName returns the unqualified name of a type, ignoring generic type arguments.
+func baseName(typ reflect.Type) string {
+	name, _, _ := strings.Cut(typ.Name(), "[")
+
+	return name
+}
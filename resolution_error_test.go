@@ -0,0 +1,109 @@
+package needle_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedle_ResolutionErrorChain(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type Repo struct{}
+
+	type Service struct {
+		Repo *Repo `needle:"inject"`
+	}
+
+	type Handler struct {
+		Service Service `needle:"inject,recurse"`
+	}
+
+	var handler Handler
+
+	err := needle.InjectStructFields(&handler)
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+
+	var resErr *needle.ResolutionError
+
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, reflect.TypeFor[Repo](), resErr.Type)
+	assert.Equal(t, []reflect.Type{reflect.TypeFor[Handler](), reflect.TypeFor[Service]()}, resErr.Chain)
+	assert.Empty(t, resErr.Lifetime)
+	assert.Contains(t, resErr.Error(), "(required by github.com/goplexhq/needle_test.Handler -> "+
+		"github.com/goplexhq/needle_test.Service)")
+}
+
+func TestNeedle_ResolutionErrorScopeSuggestions(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{}
+
+	require.NoError(t, needle.Register[testStruct](needle.Scoped, needle.WithScope("request1")))
+
+	_, err := needle.Resolve[testStruct](needle.WithScope("request2"))
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+
+	var resErr *needle.ResolutionError
+
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, needle.Scoped, resErr.Lifetime)
+	assert.Equal(t, "request2", resErr.Scope)
+	assert.Equal(t, []string{`scope "request1"`}, resErr.Suggestions)
+	assert.Equal(t, "service not registered in the registry: github.com/goplexhq/needle_test.testStruct "+
+		`[SCOPED in scope "request2"]; did you mean scope "request1"?`, resErr.Error())
+
+	_, err = needle.Resolve[testStruct]()
+	require.ErrorIs(t, err, needle.ErrEmptyScope)
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, []string{`scope "request1"`}, resErr.Suggestions)
+}
+
+func TestNeedle_ResolutionErrorThreadSuggestions(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{}
+
+	require.NoError(t, needle.Register[testStruct](needle.ThreadLocal, needle.WithThreadID("thread1")))
+
+	_, err := needle.Resolve[testStruct](needle.WithThreadID("thread2"))
+
+	var resErr *needle.ResolutionError
+
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, needle.ThreadLocal, resErr.Lifetime)
+	assert.Equal(t, "thread2", resErr.ThreadID)
+	assert.Equal(t, []string{`thread "thread1"`}, resErr.Suggestions)
+}
+
+type ResolutionErrorRepo struct{}
+
+func TestNeedle_ResolutionErrorTypeSuggestions(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type resolutionErrorRepo struct{}
+
+	require.NoError(t, needle.Register[resolutionErrorRepo](needle.Singleton))
+	require.NoError(t, needle.RegisterSingletonInstance(&struct{ name string }{}))
+
+	_, err := needle.Resolve[ResolutionErrorRepo]()
+
+	var resErr *needle.ResolutionError
+
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, []string{"github.com/goplexhq/needle_test.resolutionErrorRepo"}, resErr.Suggestions)
+
+	_, err = needle.Resolve[*resolutionErrorRepo]()
+	require.ErrorAs(t, err, &resErr)
+	assert.Equal(t, []string{"github.com/goplexhq/needle_test.resolutionErrorRepo"}, resErr.Suggestions)
+
+	_, err = needle.ResolveByName("example.com/other.ResolutionErrorRepo")
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+	require.ErrorAs(t, err, &resErr)
+	assert.Nil(t, resErr.Type)
+	assert.Equal(t, "example.com/other.ResolutionErrorRepo", resErr.Name)
+	assert.Equal(t, []string{"github.com/goplexhq/needle_test.resolutionErrorRepo"}, resErr.Suggestions)
+}
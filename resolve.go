@@ -1,6 +1,7 @@
 package needle
 
 import (
+	"errors"
 	"fmt"
 	"reflect"
 
@@ -78,46 +79,234 @@ func Resolve[T any](optFuncs ...ResolutionOptionFunc) (*T, error) {
 //	}
 func ResolveFromRegistry[T any](registry *Registry, optFuncs ...ResolutionOptionFunc) (*T, error) {
 	t := reflect.TypeFor[T]()
-	name := internal.ServiceName(t)
-
 	opt := newResolutionOptions(optFuncs...)
 
-	entry, exists := registry.has(name)
-	if !exists {
-		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, name)
+	i, err := resolveType(registry, t, opt)
+	if err != nil {
+		return nil, err
 	}
 
-	if entry.lifetime == Scoped && opt.scope == "" {
-		return nil, ErrEmptyScope
+	v, valid := i.(*T)
+	if !valid {
+		return nil, fmt.Errorf("%w: %s", ErrServiceTypeMismatch, internal.ServiceName(t))
 	}
 
-	if entry.lifetime == ThreadLocal && opt.threadID == "" {
+	return v, nil
+}
+
+// ResolveType resolves an instance of a type discovered at runtime from the global registry.
+// Returns a pointer to the resolved instance or an error if the instance cannot be resolved.
+//
+// The optFuncs parameter allows for optional configuration of the resolution, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for resolving scoped dependencies. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//     Optional and defaults to the current goroutine ID if not provided and resolving thread-local instances.
+//
+// Example:
+//
+//	val, err := needle.ResolveType(reflect.TypeFor[MyService]())
+//	if err != nil {
+//	    ...
+//	}
+func ResolveType(typ reflect.Type, optFuncs ...ResolutionOptionFunc) (any, error) {
+	ensureGlobalRegistryInitialized()
+
+	return ResolveTypeFromRegistry(globalRegistry, typ, optFuncs...)
+}
+
+// ResolveTypeFromRegistry resolves an instance of a type discovered at runtime from the given registry.
+// Returns a pointer to the resolved instance or an error if the instance cannot be resolved.
+//
+// The optFuncs parameter allows for optional configuration of the resolution, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for resolving scoped dependencies. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//     Optional and defaults to the current goroutine ID if not provided and resolving thread-local instances.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	val, err := needle.ResolveTypeFromRegistry(registry, reflect.TypeFor[MyService]())
+//	if err != nil {
+//	    ...
+//	}
+func ResolveTypeFromRegistry(registry *Registry, typ reflect.Type, optFuncs ...ResolutionOptionFunc) (any, error) {
+	return resolveType(registry, typ, newResolutionOptions(optFuncs...))
+}
+
+// ResolveByName resolves an instance of a service by its registered name from the global registry.
+// Service names are in the form "<pkg>.<service>", as returned by RegisteredServices.
+// Returns a pointer to the resolved instance or an error if the instance cannot be resolved, wrapping
+// ErrAmbiguousName if several registered services have the name.
+//
+// The optFuncs parameter allows for optional configuration of the resolution, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for resolving scoped dependencies. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//     Optional and defaults to the current goroutine ID if not provided and resolving thread-local instances.
+//
+// Example:
+//
+//	val, err := needle.ResolveByName("github.com/acme/app.MyService")
+//	if err != nil {
+//	    ...
+//	}
+func ResolveByName(name string, optFuncs ...ResolutionOptionFunc) (any, error) {
+	ensureGlobalRegistryInitialized()
+
+	return ResolveByNameFromRegistry(globalRegistry, name, optFuncs...)
+}
+
+// ResolveByNameFromRegistry resolves an instance of a service by its registered name from the given registry.
+// Service names are in the form "<pkg>.<service>", as returned by Registry.RegisteredServices.
+// Returns a pointer to the resolved instance or an error if the instance cannot be resolved, wrapping
+// ErrAmbiguousName if several registered services have the name.
+//
+// The optFuncs parameter allows for optional configuration of the resolution, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets a scope for resolving scoped dependencies. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets a thread ID for resolving thread-local dependencies.
+//     Optional and defaults to the current goroutine ID if not provided and resolving thread-local instances.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	val, err := needle.ResolveByNameFromRegistry(registry, "github.com/acme/app.MyService")
+//	if err != nil {
+//	    ...
+//	}
+func ResolveByNameFromRegistry(registry *Registry, name string, optFuncs ...ResolutionOptionFunc) (any, error) {
+	opt := newResolutionOptions(optFuncs...)
+
+	typ, err := registry.lookup(name)
+	if err != nil {
+		resErr := newResolutionError(registry, nil, "", opt, err)
+		resErr.Name = name
+
+		if errors.Is(err, ErrNotRegistered) {
+			resErr.Suggestions = registry.similarNames(name)
+		}
+
+		return nil, resErr
+	}
+
+	return resolveType(registry, typ, opt)
+}
+
+// resolveType resolves the instance of the given type from the registry, notifies the observers and records a
+// span with the tracer.
+// The thread ID defaults to the current goroutine ID when resolving thread-local instances.
+func resolveType(registry *Registry, typ reflect.Type, opt *ResolutionOptions) (any, error) {
+	entry, exists := registry.has(typ)
+
+	if exists && entry.lifetime == ThreadLocal && opt.threadID == "" {
 		opt.threadID = internal.GetGoroutineID()
 	}
 
-	i, err := resolveName(registry, name, opt)
+	opt, span := registry.startSpan(opt, SpanResolve, typ, entry.lifetime)
+	observeEnd := registry.observeResolve(typ, entry.lifetime, opt)
+	finish := func(cacheHit bool, err error) {
+		observeEnd(cacheHit, err)
+		span.SetAttributes(cacheHitAttribute(cacheHit))
+		span.End(err)
+	}
+
+	if !exists {
+		err := newResolutionError(registry, typ, "", opt, ErrNotRegistered)
+		finish(false, err)
+
+		return nil, err
+	}
+
+	keys, err := registry.strategies[entry.lifetime].Keys(opt)
 	if err != nil {
+		err = newResolutionError(registry, typ, entry.lifetime, opt, err)
+		finish(false, err)
+
 		return nil, err
 	}
 
-	v, valid := i.(*T)
-	if !valid {
-		return nil, fmt.Errorf("%w: %s", ErrServiceTypeMismatch, name)
+	if registry.strict {
+		if err := registry.checkCaptive(typ, entry.lifetime, opt); err != nil {
+			finish(false, err)
+
+			return nil, err
+		}
 	}
 
-	return v, nil
+	inst, cacheHit, err := resolveInstance(registry, typ, keys, opt)
+	finish(cacheHit, err)
+
+	return inst, err
 }
 
-// resolveName resolves the instance by its name from the registry.
-func resolveName(registry *Registry, name string, opt *ResolutionOptions) (any, error) {
-	entry, exists := registry.get(name, opt)
+// resolveInstance resolves the instance of the given type held under the first of the keys holding one, and
+// reports whether an instance held by the registry was returned. Instances are created by the strategy of the
+// service's lifetime, on first resolution when the strategy retains them, in which case concurrent resolutions
+// share a single creation. Scoped instances held by an ancestor scope are created within that scope.
+func resolveInstance(registry *Registry, typ reflect.Type, keys []string, opt *ResolutionOptions) (any, bool, error) {
+	entry, key, exists := registry.get(typ, keys)
 	if !exists {
-		return nil, fmt.Errorf("%w: %s", ErrNotRegistered, name)
+		return nil, false, newResolutionError(registry, typ, entry.lifetime, opt, ErrNotRegistered)
+	}
+
+	if entry.lifetime == Scoped && key != opt.scope {
+		opt = opt.withScope(key)
+	}
+
+	if registry.retentions[entry.lifetime] == RetainNone {
+		return createInstance(registry, entry, key, opt)
+	}
+
+	if entry.value.IsValid() {
+		return entry.value.Interface(), true, nil
+	}
+
+	flightKey := flightKey{typ: typ, lifetime: entry.lifetime, key: key}
+
+	return registry.createOnce(flightKey, opt, func(opt *ResolutionOptions) (any, bool, error) {
+		if current, _, found := registry.get(typ, []string{key}); found && current.value.IsValid() {
+			return current.value.Interface(), true, nil
+		}
+
+		return createInstance(registry, entry, key, opt)
+	})
+}
+
+// createInstance creates an instance of a service with the strategy of its lifetime, and holds it under the key
+// when the strategy retains instances. Reports whether an existing instance was returned instead.
+func createInstance(registry *Registry, entry serviceEntry, key string, opt *ResolutionOptions) (any, bool, error) {
+	strategy := registry.strategies[entry.lifetime]
+
+	inst, reused, err := strategy.Create(entry.typ, opt, func() (any, error) {
+		value, err := construct(registry, entry, opt)
+		if err != nil {
+			return nil, err
+		}
+
+		return value.Interface(), nil
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if registry.retentions[entry.lifetime] == RetainNone {
+		return inst, reused, nil
+	}
+
+	value, stored, expired := registry.store(entry, key, reflect.ValueOf(inst))
+	if expired.value.IsValid() {
+		registry.retire(entry, expired)
 	}
 
-	if entry.lifetime == Transient {
-		return reflect.New(entry.value.Type()).Interface(), nil
+	if stored && entry.refresh > 0 {
+		registry.startRefresh(entry)
 	}
 
-	return entry.value.Interface(), nil
+	return value.Interface(), !stored, nil
 }
@@ -2,6 +2,7 @@ package needle_test
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 	"testing"
 
@@ -217,3 +218,85 @@ func TestNeedle_ResolveNotRegistered(t *testing.T) {
 	require.ErrorIs(t, err, needle.ErrNotRegistered)
 	assert.Nil(t, val)
 }
+
+func TestNeedle_ResolveType(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{ name string }
+
+	require.NoError(t, needle.RegisterSingletonInstance(&testStruct{name: "myStruct"}))
+
+	val, err := needle.ResolveType(reflect.TypeFor[testStruct]())
+	require.NoError(t, err)
+	require.IsType(t, &testStruct{}, val)               //nolint:exhaustruct
+	assert.Equal(t, "myStruct", val.(*testStruct).name) //nolint:forcetypeassert
+
+	_, err = needle.ResolveType(reflect.TypeFor[struct{ other int }]())
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+}
+
+func TestNeedle_ResolveTypeFromRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{ name string }
+
+	registry := needle.NewRegistry()
+
+	opt := needle.WithScope("scope")
+	require.NoError(t, needle.RegisterScopedInstanceToRegistry(registry, &testStruct{name: "myStruct"}, opt))
+
+	_, err := needle.ResolveTypeFromRegistry(registry, reflect.TypeFor[testStruct]())
+	require.ErrorIs(t, err, needle.ErrEmptyScope)
+
+	val, err := needle.ResolveTypeFromRegistry(registry, reflect.TypeFor[testStruct](), opt)
+	require.NoError(t, err)
+	assert.Equal(t, "myStruct", val.(*testStruct).name) //nolint:forcetypeassert
+}
+
+func TestNeedle_ResolveByName(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{ name string }
+
+	require.NoError(t, needle.RegisterSingletonInstance(&testStruct{name: "myStruct"}))
+
+	val, err := needle.ResolveByName("github.com/goplexhq/needle_test.testStruct")
+	require.NoError(t, err)
+	assert.Equal(t, "myStruct", val.(*testStruct).name) //nolint:forcetypeassert
+
+	_, err = needle.ResolveByName("github.com/goplexhq/needle_test.unknown")
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+}
+
+func TestNeedle_ResolveByNameFromRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{ name string }
+
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testStruct{name: "myStruct"}))
+
+	val, err := needle.ResolveByNameFromRegistry(registry, "github.com/goplexhq/needle_test.testStruct")
+	require.NoError(t, err)
+	assert.Equal(t, "myStruct", val.(*testStruct).name) //nolint:forcetypeassert
+}
+
+func TestNeedle_ResolveByNameAmbiguous(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	{
+		type testStruct struct{}
+
+		require.NoError(t, needle.RegisterToRegistry[testStruct](registry, needle.Transient))
+	}
+
+	{
+		type testStruct struct{}
+
+		require.NoError(t, needle.RegisterToRegistry[testStruct](registry, needle.Transient))
+	}
+
+	_, err := needle.ResolveByNameFromRegistry(registry, "github.com/goplexhq/needle_test.testStruct")
+	require.ErrorIs(t, err, needle.ErrAmbiguousName)
+}
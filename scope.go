@@ -0,0 +1,242 @@
+package needle
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ScopeOptions holds configuration options for opening a scope.
+type ScopeOptions struct {
+	parent string
+}
+
+// ScopeOptionFunc is a function that modifies a ScopeOptions struct.
+type ScopeOptionFunc func(*ScopeOptions)
+
+// WithParentScope sets the parent of the scope being opened. Resolutions within the scope fall back to the scoped
+// services of its parent, and of the parent's ancestors, and closing the parent closes the scope.
+//
+// Example:
+//
+//	err := needle.OpenScope("request1", needle.WithParentScope("session1"))
+func WithParentScope(parent string) ScopeOptionFunc {
+	return func(o *ScopeOptions) {
+		o.parent = parent
+	}
+}
+
+// newScopeOptions creates a new ScopeOptions struct from the provided option functions.
+func newScopeOptions(optFuncs ...ScopeOptionFunc) *ScopeOptions {
+	opt := &ScopeOptions{parent: ""}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	return opt
+}
+
+// OpenScope opens a scope in the global registry. See OpenScopeInRegistry for details.
+func OpenScope(scope string, optFuncs ...ScopeOptionFunc) error {
+	ensureGlobalRegistryInitialized()
+
+	return OpenScopeInRegistry(globalRegistry, scope, optFuncs...)
+}
+
+// OpenScopeInRegistry opens a scope in the registry, optionally nested in a parent scope.
+// Returns an error if the scope is empty or already exists, or if the parent scope does not exist.
+//
+// Scoped services are registered in an opened scope as in any scope. When a scoped service is not registered in
+// the scope, it is resolved from the closest ancestor scope registering it, with the dependencies of that scope.
+// An opened scope is kept until it is closed with CloseScopeInRegistry, even once it holds no instances.
+//
+// The optFuncs parameter allows for optional configuration of the scope.
+//
+// Available options:
+//   - WithParentScope(parent string): Sets the parent of the scope.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	err := needle.OpenScopeInRegistry(registry, "request1", needle.WithParentScope("session1"))
+//	if err != nil {
+//	    ...
+//	}
+func OpenScopeInRegistry(registry *Registry, scope string, optFuncs ...ScopeOptionFunc) error {
+	opt := newScopeOptions(optFuncs...)
+
+	if err := registry.openScope(scope, opt.parent); err != nil {
+		return err
+	}
+
+	registry.observeScopes([]string{scope}, nil)
+
+	return nil
+}
+
+// CloseScope closes a scope of the global registry and its descendants. See CloseScopeInRegistry for details.
+func CloseScope(scope string) error {
+	ensureGlobalRegistryInitialized()
+
+	return CloseScopeInRegistry(globalRegistry, scope)
+}
+
+// CloseScopeInRegistry closes a scope of the registry along with its descendants, removing and disposing the
+// instances they hold, children first. A registration is removed once its last scoped instance is removed.
+// Pooled instances borrowed within the closed scopes are then returned to their pool, see ReleaseToRegistry.
+// Returns an error if the scope does not exist or an instance fails to be disposed.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	err := needle.CloseScopeInRegistry(registry, "session1")
+//	if err != nil {
+//	    ...
+//	}
+func CloseScopeInRegistry(registry *Registry, scope string) error {
+	removed, found := registry.closeScope(scope)
+	if !found {
+		return fmt.Errorf("%w: %q", ErrScopeNotFound, scope)
+	}
+
+	err := registry.dispose(removed.instances...)
+
+	registry.release(removed)
+
+	return err
+}
+
+// openScope records a new scope and its parent. An existing parent scope that was not opened is kept open from
+// then on, so that the scope does not lose its parent when the parent holds no instances.
+func (r *Registry) openScope(scope, parent string) error {
+	if scope == "" {
+		return ErrEmptyScope
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if r.hasScope(scope) {
+		return fmt.Errorf("%w: %q", ErrScopeExists, scope)
+	}
+
+	if parent != "" {
+		if !r.hasScope(parent) {
+			return fmt.Errorf("%w: %q", ErrScopeNotFound, parent)
+		}
+
+		if _, opened := r.scopeParents[parent]; !opened {
+			r.scopeParents[parent] = ""
+		}
+	}
+
+	r.scopeParents[scope] = parent
+
+	return nil
+}
+
+// closeScope removes a scope and its descendants, and returns the removed instances, in disposal order, the
+// closed scopes, children first, and the registrations removed along with their last scope.
+func (r *Registry) closeScope(scope string) (removal, bool) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var removed removal
+
+	if !r.hasScope(scope) {
+		return removed, false
+	}
+
+	removed.scopes = r.scopeDescendants(scope)
+	order := disposalOrder(r.registeredServices)
+
+	for _, closing := range removed.scopes {
+		var instances []heldInstance
+
+		for typ, inst := range r.instances[Scoped][closing] {
+			if inst.value.IsValid() {
+				instances = append(instances, newHeldInstance(typ, Scoped, closing, inst.value))
+			}
+		}
+
+		slices.SortFunc(instances, func(a, b heldInstance) int {
+			return order[a.typ] - order[b.typ]
+		})
+
+		removed.instances = append(removed.instances, instances...)
+		services := r.instances[Scoped][closing]
+
+		delete(r.instances[Scoped], closing)
+		delete(r.scopeParents, closing)
+
+		for typ := range services {
+			if entry, registered := r.registeredServices[typ]; registered && !r.holdsInstances(typ) {
+				removed.services = append(removed.services, entry)
+				delete(r.registeredServices, typ)
+			}
+		}
+	}
+
+	return removed, true
+}
+
+// scopeDescendants returns the given scope and its descendants, children before their parents, siblings sorted.
+// The caller must hold the registry lock.
+func (r *Registry) scopeDescendants(scope string) []string {
+	var children []string
+
+	for child, parent := range r.scopeParents {
+		if parent == scope {
+			children = append(children, child)
+		}
+	}
+
+	slices.Sort(children)
+
+	var scopes []string
+	for _, child := range children {
+		scopes = append(scopes, r.scopeDescendants(child)...)
+	}
+
+	return append(scopes, scope)
+}
+
+// releaseScopes notifies the lifetime strategies implementing ScopeCloser that the scopes were closed.
+func (r *Registry) releaseScopes(scopes []string) {
+	for _, lifetime := range sortedLifetimes(r.strategies) {
+		if closer, ok := r.strategies[lifetime].(ScopeCloser); ok {
+			for _, scope := range scopes {
+				closer.CloseScope(scope)
+			}
+		}
+	}
+}
+
+// hasScope reports whether a scope was opened or holds instances.
+// The caller must hold the registry lock.
+func (r *Registry) hasScope(scope string) bool {
+	_, held := r.instances[Scoped][scope]
+	_, opened := r.scopeParents[scope]
+
+	return held || opened
+}
+
+// scopeExists reports whether a scope was opened or holds instances.
+func (r *Registry) scopeExists(scope string) bool {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return r.hasScope(scope)
+}
+
+// scopeChain returns the given scope followed by its ancestors, closest first.
+func (r *Registry) scopeChain(scope string) []string {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	chain := []string{scope}
+	for parent := r.scopeParents[scope]; parent != ""; parent = r.scopeParents[parent] {
+		chain = append(chain, parent)
+	}
+
+	return chain
+}
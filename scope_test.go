@@ -0,0 +1,125 @@
+package needle_test
+
+import (
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedleScopeConnection struct {
+	closed *[]string
+}
+
+func (c *testNeedleScopeConnection) Close() error {
+	*c.closed = append(*c.closed, "connection")
+
+	return nil
+}
+
+type testNeedleScopeMessage struct {
+	Connection *testNeedleScopeConnection `needle:"inject"`
+	closed     *[]string
+}
+
+func (m *testNeedleScopeMessage) Close() error {
+	*m.closed = append(*m.closed, "message")
+
+	return nil
+}
+
+func TestNeedle_NestedScopeResolution(t *testing.T) {
+	type Config struct{}
+
+	type Operation struct {
+		Connection *testNeedleScopeConnection `needle:"inject"`
+		Config     *Config                    `needle:"inject"`
+	}
+
+	var closed []string
+
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterToRegistry[Config](registry, needle.Singleton))
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "connection1"))
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleScopeConnection](registry, needle.Scoped,
+		func() *testNeedleScopeConnection {
+			return &testNeedleScopeConnection{closed: &closed}
+		}, needle.WithScope("connection1")))
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "message1", needle.WithParentScope("connection1")))
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "operation1", needle.WithParentScope("message1")))
+	require.NoError(t, needle.RegisterToRegistry[Operation](registry, needle.Scoped, needle.WithScope("operation1")))
+
+	operation, err := needle.ResolveFromRegistry[Operation](registry, needle.WithScope("operation1"))
+	require.NoError(t, err)
+
+	connection, err := needle.ResolveFromRegistry[testNeedleScopeConnection](registry, needle.WithScope("message1"))
+	require.NoError(t, err)
+	assert.Same(t, connection, operation.Connection)
+
+	config, err := needle.ResolveFromRegistry[Config](registry, needle.WithScope("operation1"))
+	require.NoError(t, err)
+	assert.Same(t, config, operation.Config)
+
+	_, err = needle.ResolveFromRegistry[Operation](registry, needle.WithScope("message1"))
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+}
+
+func TestNeedle_CloseScopeClosesChildren(t *testing.T) {
+	var closed []string
+
+	observer := &testNeedleObserver{} //nolint:exhaustruct
+	registry := needle.NewRegistry(needle.WithObserver(observer))
+
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Scoped,
+		&testNeedleScopeConnection{closed: &closed}, needle.WithScope("connection1")))
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "message1", needle.WithParentScope("connection1")))
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "message2", needle.WithParentScope("connection1")))
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleScopeMessage](registry, needle.Scoped,
+		func(connection *testNeedleScopeConnection) *testNeedleScopeMessage {
+			return &testNeedleScopeMessage{Connection: connection, closed: &closed}
+		}, needle.WithScope("message1")))
+
+	message, err := needle.ResolveFromRegistry[testNeedleScopeMessage](registry, needle.WithScope("message1"))
+	require.NoError(t, err)
+	assert.NotNil(t, message.Connection)
+
+	// an opened scope is kept once it holds no instances
+	require.NoError(t, needle.UnregisterFromRegistry[testNeedleScopeConnection](registry,
+		needle.WithScope("connection1")))
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, needle.Scoped,
+		&testNeedleScopeConnection{closed: &closed}, needle.WithScope("connection1")))
+
+	closed = nil
+
+	require.NoError(t, needle.CloseScopeInRegistry(registry, "connection1"))
+	assert.Equal(t, []string{"message", "connection"}, closed)
+	assert.Equal(t, []string{
+		"create connection1",
+		"create message1",
+		"create message2",
+		"close message1",
+		"close message2",
+		"close connection1",
+	}, observer.scopes)
+	assert.Empty(t, registry.RegisteredServices())
+
+	_, err = needle.ResolveFromRegistry[testNeedleScopeMessage](registry, needle.WithScope("message1"))
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+}
+
+func TestNeedle_ScopeErrors(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	require.ErrorIs(t, needle.OpenScope(""), needle.ErrEmptyScope)
+	require.ErrorIs(t, needle.OpenScope("request1", needle.WithParentScope("session1")), needle.ErrScopeNotFound)
+	require.ErrorIs(t, needle.CloseScope("session1"), needle.ErrScopeNotFound)
+
+	require.NoError(t, needle.OpenScope("session1"))
+	require.ErrorIs(t, needle.OpenScope("session1"), needle.ErrScopeExists)
+	require.NoError(t, needle.OpenScope("request1", needle.WithParentScope("session1")))
+	require.NoError(t, needle.CloseScope("request1"))
+	require.NoError(t, needle.OpenScope("request1", needle.WithParentScope("session1")))
+	require.NoError(t, needle.CloseScope("session1"))
+	require.ErrorIs(t, needle.CloseScope("request1"), needle.ErrScopeNotFound)
+}
@@ -0,0 +1,249 @@
+package needle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// ShutdownOptions holds configuration options for shutting down a registry.
+type ShutdownOptions struct {
+	serviceTimeout time.Duration
+}
+
+// ShutdownOptionFunc is a function that modifies a ShutdownOptions struct.
+type ShutdownOptionFunc func(*ShutdownOptions)
+
+// WithServiceTimeout sets the maximum time spent disposing a single service instance during Shutdown.
+// By default, instances are only bound by the deadline of the context passed to Shutdown.
+//
+// Example:
+//
+//	report, err := registry.Shutdown(ctx, needle.WithServiceTimeout(5*time.Second))
+func WithServiceTimeout(timeout time.Duration) ShutdownOptionFunc {
+	return func(o *ShutdownOptions) {
+		o.serviceTimeout = timeout
+	}
+}
+
+// newShutdownOptions creates a new ShutdownOptions struct from the provided option functions.
+func newShutdownOptions(optFuncs ...ShutdownOptionFunc) *ShutdownOptions {
+	opt := &ShutdownOptions{serviceTimeout: 0}
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	return opt
+}
+
+// serviceContext derives the context bounding the disposal of a single service instance.
+func (o *ShutdownOptions) serviceContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	if o.serviceTimeout > 0 {
+		return context.WithTimeout(ctx, o.serviceTimeout)
+	}
+
+	return context.WithCancel(ctx)
+}
+
+// ShutdownReport describes the outcome of shutting down a registry.
+type ShutdownReport struct {
+	Services []ServiceShutdown // Disposed instances, in disposal order.
+	Duration time.Duration     // Total duration of the shutdown.
+}
+
+// ServiceShutdown describes the disposal of a service instance during a shutdown.
+type ServiceShutdown struct {
+	Name     string        // Display name of the service.
+	Type     reflect.Type  // Type of the service.
+	Lifetime Lifetime      // Lifetime of the service.
+	Scope    string        // Scope holding the instance, empty unless the service is Scoped.
+	ThreadID string        // Thread ID holding the instance, empty unless the service is ThreadLocal.
+	Duration time.Duration // Time spent disposing the instance.
+	TimedOut bool          // Whether disposal was abandoned because a deadline was exceeded.
+	Err      error         // Error that occurred while disposing the instance, nil on success.
+}
+
+// Shutdown disposes every instance held by the global registry and clears it.
+// See Registry.Shutdown for details.
+func Shutdown(ctx context.Context, optFuncs ...ShutdownOptionFunc) (ShutdownReport, error) {
+	ensureGlobalRegistryInitialized()
+
+	return globalRegistry.Shutdown(ctx, optFuncs...)
+}
+
+// Shutdown disposes every instance held by the registry, whether singleton, scoped or thread-local, and clears
+// the registry. Returns a report of the disposed instances and the joined errors of every failure.
+//
+// Instances implementing PreDestroyer, Shutdowner or io.Closer are disposed one at a time in reverse dependency
+// order, so that services are disposed before the services they depend on. Each disposal is bound by the context
+// and by the timeout set with WithServiceTimeout. Failures and timeouts are reported and do not stop the shutdown.
+// Pooled instances are not held by the registry and are not disposed. Singletons replaced after their TTL or by a
+// background refresh are disposed first, without waiting for their grace period.
+//
+// Example:
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+//	defer cancel()
+//
+//	report, err := registry.Shutdown(ctx, needle.WithServiceTimeout(5*time.Second))
+//	if err != nil {
+//	    ...
+//	}
+func (r *Registry) Shutdown(ctx context.Context, optFuncs ...ShutdownOptionFunc) (ShutdownReport, error) {
+	opt := newShutdownOptions(optFuncs...)
+	start := time.Now()
+	removed := r.drain()
+
+	report := ShutdownReport{Services: make([]ServiceShutdown, 0, len(removed.instances)), Duration: 0}
+
+	var errs []error
+
+	for _, inst := range removed.instances {
+		serviceCtx, cancel := opt.serviceContext(ctx)
+		disposeStart := time.Now()
+		disposed, err := r.disposeHeld(serviceCtx, inst)
+		duration := time.Since(disposeStart)
+
+		cancel()
+
+		if !disposed {
+			continue
+		}
+
+		r.observeDispose(inst, duration, err)
+
+		report.Services = append(report.Services, ServiceShutdown{
+			Name:     internal.ServiceName(inst.typ),
+			Type:     inst.typ,
+			Lifetime: inst.lifetime,
+			Scope:    inst.scope,
+			ThreadID: inst.threadID,
+			Duration: duration,
+			TimedOut: errors.Is(err, context.DeadlineExceeded),
+			Err:      err,
+		})
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	r.release(removed)
+
+	report.Duration = time.Since(start)
+
+	return report, errors.Join(errs...)
+}
+
+// heldInstance is an instance held by a registry, along with where it is held.
+type heldInstance struct {
+	typ      reflect.Type
+	lifetime Lifetime
+	key      string
+	scope    string
+	threadID string
+	value    reflect.Value
+}
+
+// newHeldInstance creates a heldInstance for an instance held under a key of a lifetime. The key is the scope of
+// Scoped instances and the thread ID of ThreadLocal instances.
+func newHeldInstance(typ reflect.Type, lifetime Lifetime, key string, value reflect.Value) heldInstance {
+	inst := heldInstance{typ: typ, lifetime: lifetime, key: key, scope: "", threadID: "", value: value}
+
+	switch lifetime {
+	case Scoped:
+		inst.scope = key
+	case ThreadLocal:
+		inst.threadID = key
+	}
+
+	return inst
+}
+
+// drain clears the registry and returns the created instances it held, in reverse dependency order, preceded by
+// the expired or refreshed instances waiting to be disposed, along with the closed scopes and the removed
+// registrations.
+func (r *Registry) drain() removal {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	retired := r.stopRefreshes()
+	instances := r.heldInstances()
+	order := disposalOrder(r.registeredServices)
+
+	slices.SortStableFunc(instances, func(a, b heldInstance) int {
+		if diff := order[a.typ] - order[b.typ]; diff != 0 {
+			return diff
+		}
+
+		if a.lifetime != b.lifetime {
+			return strings.Compare(string(a.lifetime), string(b.lifetime))
+		}
+
+		return strings.Compare(a.key, b.key)
+	})
+
+	removed := r.clear()
+	removed.instances = append(retired, instances...)
+
+	return removed
+}
+
+// heldInstances returns the created instances held by the registry, whatever their lifetime.
+// The caller must hold the registry lock.
+func (r *Registry) heldInstances() []heldInstance {
+	var instances []heldInstance
+
+	for lifetime, keys := range r.instances {
+		for key, services := range keys {
+			for typ, inst := range services {
+				if inst.value.IsValid() {
+					instances = append(instances, newHeldInstance(typ, lifetime, key, inst.value))
+				}
+			}
+		}
+	}
+
+	return instances
+}
+
+// disposalOrder ranks the registered types so that every service ranks before the services it depends on.
+func disposalOrder(entries map[reflect.Type]serviceEntry) map[reflect.Type]int {
+	var (
+		sorted  []reflect.Type
+		visited = make(map[reflect.Type]bool, len(entries))
+		visit   func(typ reflect.Type)
+	)
+
+	visit = func(typ reflect.Type) {
+		if visited[typ] {
+			return
+		}
+
+		visited[typ] = true
+
+		if entry, found := entries[typ]; found {
+			for _, dep := range entry.dependencies() {
+				visit(dep)
+			}
+		}
+
+		sorted = append(sorted, typ)
+	}
+
+	for _, typ := range sortedTypes(entries) {
+		visit(typ)
+	}
+
+	order := make(map[reflect.Type]int, len(sorted))
+	for idx, typ := range sorted {
+		order[typ] = len(sorted) - idx
+	}
+
+	return order
+}
@@ -0,0 +1,138 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testNeedleShutdownLog struct {
+	mutex sync.Mutex
+	names []string
+}
+
+func (l *testNeedleShutdownLog) add(name string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+
+	l.names = append(l.names, name)
+}
+
+type testNeedleShutdownDatabase struct{ log *testNeedleShutdownLog }
+
+func (d *testNeedleShutdownDatabase) Close() error {
+	d.log.add("database")
+
+	return nil
+}
+
+type testNeedleShutdownRepo struct {
+	Database *testNeedleShutdownDatabase `needle:"inject"`
+}
+
+func (r *testNeedleShutdownRepo) Shutdown(context.Context) error {
+	r.Database.log.add("repo")
+
+	return nil
+}
+
+type testNeedleShutdownSession struct {
+	Repo *testNeedleShutdownRepo `needle:"inject"`
+}
+
+func (s *testNeedleShutdownSession) Close() error {
+	s.Repo.Database.log.add("session")
+
+	return nil
+}
+
+func TestNeedle_Shutdown(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	log := &testNeedleShutdownLog{} //nolint:exhaustruct
+	opt := needle.WithScope("request1")
+
+	require.NoError(t, needle.RegisterSingletonInstance(&testNeedleShutdownDatabase{log: log}))
+	require.NoError(t, needle.Register[testNeedleShutdownRepo](needle.Singleton))
+	require.NoError(t, needle.Register[testNeedleShutdownSession](needle.Scoped, opt))
+
+	_, err := needle.Resolve[testNeedleShutdownSession](opt)
+	require.NoError(t, err)
+
+	report, err := needle.Shutdown(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"session", "repo", "database"}, log.names)
+	require.Len(t, report.Services, 3)
+	assert.Equal(t, "request1", report.Services[0].Scope)
+	assert.Equal(t, needle.Scoped, report.Services[0].Lifetime)
+	assert.Empty(t, needle.RegisteredServices())
+}
+
+type testNeedleShutdownSlow struct{ release chan struct{} }
+
+func (s *testNeedleShutdownSlow) Shutdown(ctx context.Context) error {
+	select {
+	case <-s.release:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func TestNeedle_ShutdownContinuesPastFailures(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	registry := needle.NewRegistry()
+	errClose := errors.New("close failed") //nolint:err113
+
+	failing := &testNeedleCloser{name: "failing", fail: true} //nolint:exhaustruct
+	slow := &testNeedleShutdownSlow{release: make(chan struct{})}
+	thread := &testNeedleCloser{name: "thread"} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, failing))
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, slow))
+	require.NoError(t, needle.RegisterThreadLocalInstanceToRegistry(registry, thread, needle.WithThreadID("7")))
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &errClose))
+
+	report, err := registry.Shutdown(context.Background(), needle.WithServiceTimeout(10*time.Millisecond))
+	require.ErrorIs(t, err, needle.ErrDispose)
+	require.ErrorIs(t, err, errTestNeedleCloser)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Len(t, report.Services, 3)
+
+	assert.True(t, failing.closed)
+	assert.True(t, thread.closed)
+
+	var timedOut []string
+
+	for _, service := range report.Services {
+		if service.TimedOut {
+			timedOut = append(timedOut, service.Name)
+		}
+	}
+
+	assert.Equal(t, []string{"github.com/goplexhq/needle_test.testNeedleShutdownSlow"}, timedOut)
+	assert.Empty(t, registry.RegisteredServices())
+}
+
+type testNeedleShutdownPanic struct{}
+
+func (p *testNeedleShutdownPanic) Close() error {
+	panic("boom")
+}
+
+func TestNeedle_ShutdownRecoversPanic(t *testing.T) {
+	registry := needle.NewRegistry()
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testNeedleShutdownPanic{}))
+
+	_, err := registry.Shutdown(context.Background())
+	require.ErrorIs(t, err, needle.ErrDispose)
+	require.ErrorIs(t, err, needle.ErrPanic)
+	require.ErrorContains(t, err, "boom")
+}
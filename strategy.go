@@ -0,0 +1,309 @@
+package needle
+
+import (
+	"context"
+	"reflect"
+	"sync"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Retention defines which instances of the services registered with a lifetime are held by the registry.
+type Retention int
+
+const (
+	RetainNone       Retention = iota // Instances are created on every resolution and never held, e.g. Transient.
+	RetainPerKey                      // An instance is created and held on first resolution under each key.
+	RetainRegistered                  // Instances are only held under the keys the service was registered with.
+)
+
+// LifetimeStrategy defines how the instances of the services registered with a lifetime are keyed, cached,
+// created and disposed. The built-in lifetimes are implemented as strategies, and strategies implementing custom
+// lifetimes, such as an instance per tenant, are added to a registry with WithLifetime. Strategies releasing
+// instances when a scope is closed also implement ScopeCloser.
+//
+// The methods of a strategy are called concurrently, without holding the registry lock. Retention is only called
+// once, when the registry is created.
+type LifetimeStrategy interface {
+	// Keys returns the keys under which the instance of a resolution is looked up, closest first, such as a
+	// scope and its ancestors. Instances are created and registered under the first key. An error, such as
+	// ErrEmptyScope, fails the resolution or registration.
+	Keys(opt *ResolutionOptions) ([]string, error)
+
+	// Retention returns which instances are held by the registry.
+	Retention() Retention
+
+	// Create returns an instance for a resolution finding no instance held by the registry, calling create to
+	// create a new instance. Reports whether an existing instance was reused instead.
+	Create(typ reflect.Type, opt *ResolutionOptions, create func() (any, error)) (any, bool, error)
+
+	// Dispose disposes an instance removed from the registry, or released with Release, and reports whether
+	// the instance was disposable. See DisposeInstance for the default disposal.
+	Dispose(ctx context.Context, typ reflect.Type, instance any) (bool, error)
+}
+
+// ScopeCloser is implemented by the lifetime strategies releasing the instances they created within a scope, such
+// as instances per batch job, when the scope is closed. CloseScope is called for each closed scope, children first,
+// once the instances held by the registry within the scope are disposed.
+type ScopeCloser interface {
+	CloseScope(scope string)
+}
+
+// ServiceRemover is implemented by the lifetime strategies keeping state per service, such as a pool per service.
+// RemoveService is called once the registration of a service with the lifetime is removed, by Unregister, Reset or
+// Shutdown, or by closing the last scope holding it.
+type ServiceRemover interface {
+	RemoveService(typ reflect.Type)
+}
+
+// removal holds what was removed from the registry, to dispose and release once the registry lock is released.
+type removal struct {
+	instances []heldInstance // removed instances, in disposal order.
+	scopes    []string       // closed scopes.
+	services  []serviceEntry // removed registrations.
+}
+
+// release notifies the lifetime strategies implementing ScopeCloser and ServiceRemover of a removal, then the
+// observers of the closed scopes.
+func (r *Registry) release(removed removal) {
+	r.releaseScopes(removed.scopes)
+
+	for _, entry := range removed.services {
+		if remover, ok := r.strategies[entry.lifetime].(ServiceRemover); ok {
+			remover.RemoveService(entry.typ)
+		}
+	}
+
+	r.observeScopes(nil, removed.scopes)
+}
+
+// builtinStrategies returns the strategies of the built-in lifetimes of a registry.
+func builtinStrategies(registry *Registry) map[Lifetime]LifetimeStrategy {
+	return map[Lifetime]LifetimeStrategy{
+		Transient:   transientStrategy{},
+		Scoped:      scopedStrategy{registry: registry},
+		ThreadLocal: threadLocalStrategy{},
+		Singleton:   singletonStrategy{},
+		Pooled:      newPooledStrategy(registry),
+		Prototype:   transientStrategy{},
+	}
+}
+
+// transientStrategy creates an instance on every resolution. Prototypes share it, their instances being created
+// by copying their template.
+type transientStrategy struct{}
+
+// Keys implements LifetimeStrategy.
+func (transientStrategy) Keys(*ResolutionOptions) ([]string, error) {
+	return nil, nil
+}
+
+// Retention implements LifetimeStrategy.
+func (transientStrategy) Retention() Retention {
+	return RetainNone
+}
+
+// Create implements LifetimeStrategy.
+func (transientStrategy) Create(_ reflect.Type, _ *ResolutionOptions, create func() (any, error)) (any, bool, error) {
+	inst, err := create()
+
+	return inst, false, err
+}
+
+// Dispose implements LifetimeStrategy.
+func (transientStrategy) Dispose(ctx context.Context, _ reflect.Type, instance any) (bool, error) {
+	return DisposeInstance(ctx, instance)
+}
+
+// singletonStrategy holds a single instance, created on first resolution.
+type singletonStrategy struct {
+	transientStrategy
+}
+
+// Keys implements LifetimeStrategy.
+func (singletonStrategy) Keys(*ResolutionOptions) ([]string, error) {
+	return []string{""}, nil
+}
+
+// Retention implements LifetimeStrategy.
+func (singletonStrategy) Retention() Retention {
+	return RetainPerKey
+}
+
+// scopedStrategy holds an instance per scope the service is registered in. Resolutions within a nested scope fall
+// back to the instances of its ancestors.
+type scopedStrategy struct {
+	transientStrategy
+
+	registry *Registry
+}
+
+// Keys implements LifetimeStrategy.
+func (s scopedStrategy) Keys(opt *ResolutionOptions) ([]string, error) {
+	if opt.scope == "" {
+		return nil, ErrEmptyScope
+	}
+
+	return s.registry.scopeChain(opt.scope), nil
+}
+
+// Retention implements LifetimeStrategy.
+func (scopedStrategy) Retention() Retention {
+	return RetainRegistered
+}
+
+// threadLocalStrategy holds an instance per thread the service is registered for.
+type threadLocalStrategy struct {
+	transientStrategy
+}
+
+// Keys implements LifetimeStrategy.
+func (threadLocalStrategy) Keys(opt *ResolutionOptions) ([]string, error) {
+	if opt.threadID == "" {
+		return []string{internal.GetGoroutineID()}, nil
+	}
+
+	return []string{opt.threadID}, nil
+}
+
+// Retention implements LifetimeStrategy.
+func (threadLocalStrategy) Retention() Retention {
+	return RetainRegistered
+}
+
+// pooledStrategy borrows instances from a pool per service, and returns them to the pool once released or once
+// the scope they were borrowed within is closed.
+type pooledStrategy struct {
+	registry *Registry
+	pools    map[reflect.Type]*sync.Pool
+	loans    map[any]loan // instances borrowed within a scope, returned to their pool once, by instance.
+	lock     sync.Mutex
+}
+
+// loan describes an instance borrowed within a scope.
+type loan struct {
+	typ   reflect.Type
+	scope string
+}
+
+// newPooledStrategy creates the strategy of the Pooled lifetime of a registry.
+func newPooledStrategy(registry *Registry) *pooledStrategy {
+	return &pooledStrategy{
+		registry: registry,
+		pools:    make(map[reflect.Type]*sync.Pool),
+		loans:    make(map[any]loan),
+		lock:     sync.Mutex{},
+	}
+}
+
+// Keys implements LifetimeStrategy.
+func (*pooledStrategy) Keys(*ResolutionOptions) ([]string, error) {
+	return nil, nil
+}
+
+// Retention implements LifetimeStrategy.
+func (*pooledStrategy) Retention() Retention {
+	return RetainNone
+}
+
+// Create implements LifetimeStrategy.
+func (s *pooledStrategy) Create(
+	typ reflect.Type,
+	opt *ResolutionOptions,
+	create func() (any, error),
+) (any, bool, error) {
+	pool := s.pool(typ)
+
+	inst, reused := pool.Get(), true
+	if inst == nil {
+		var err error
+		if inst, err = create(); err != nil {
+			return nil, false, err
+		}
+
+		reused = false
+	}
+
+	if opt.scope != "" && s.registry.scopeExists(opt.scope) {
+		s.lock.Lock()
+		s.loans[inst] = loan{typ: typ, scope: opt.scope}
+		s.lock.Unlock()
+	}
+
+	return inst, reused, nil
+}
+
+// Dispose implements LifetimeStrategy. Pooled instances are not held by the registry, so it is only called for
+// instances disposed explicitly.
+func (*pooledStrategy) Dispose(ctx context.Context, _ reflect.Type, instance any) (bool, error) {
+	return DisposeInstance(ctx, instance)
+}
+
+// release returns an instance of the given service to its pool, after resetting it if it implements Resetter.
+// An instance borrowed within a scope is no longer returned when the scope is closed.
+func (s *pooledStrategy) release(typ reflect.Type, instance any) {
+	s.lock.Lock()
+	delete(s.loans, instance)
+	s.lock.Unlock()
+
+	s.giveBack(typ, instance)
+}
+
+// CloseScope implements ScopeCloser. The instances borrowed within the scope and not released yet are returned to
+// their pool.
+func (s *pooledStrategy) CloseScope(scope string) {
+	returned := make(map[any]reflect.Type)
+
+	s.lock.Lock()
+
+	for inst, borrowed := range s.loans {
+		if borrowed.scope == scope {
+			returned[inst] = borrowed.typ
+			delete(s.loans, inst)
+		}
+	}
+
+	s.lock.Unlock()
+
+	for inst, typ := range returned {
+		s.giveBack(typ, inst)
+	}
+}
+
+// RemoveService implements ServiceRemover. The idle instances of the service are dropped, and its instances
+// borrowed within a scope are no longer returned to a pool when the scope is closed.
+func (s *pooledStrategy) RemoveService(typ reflect.Type) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	delete(s.pools, typ)
+
+	for inst, borrowed := range s.loans {
+		if borrowed.typ == typ {
+			delete(s.loans, inst)
+		}
+	}
+}
+
+// giveBack resets an instance no longer borrowed and puts it back into the pool of its service.
+func (s *pooledStrategy) giveBack(typ reflect.Type, instance any) {
+	if resetter, ok := instance.(Resetter); ok {
+		resetter.Reset()
+	}
+
+	s.pool(typ).Put(instance)
+}
+
+// pool returns the pool holding the idle instances of a type, creating it if needed.
+func (s *pooledStrategy) pool(typ reflect.Type) *sync.Pool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	pool, found := s.pools[typ]
+	if !found {
+		pool = &sync.Pool{New: nil}
+		s.pools[typ] = pool
+	}
+
+	return pool
+}
@@ -0,0 +1,241 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testNeedlePerTenant needle.Lifetime = "PER_TENANT"
+
+type testNeedleTenantKey struct{}
+
+var errTestNeedleNoTenant = errors.New("no tenant in context")
+
+// testNeedleTenantStrategy holds an instance per tenant, read from the context of the resolution.
+type testNeedleTenantStrategy struct {
+	disposed []any
+}
+
+func (s *testNeedleTenantStrategy) Keys(opt *needle.ResolutionOptions) ([]string, error) {
+	tenant, ok := opt.Context().Value(testNeedleTenantKey{}).(string)
+	if !ok {
+		return nil, errTestNeedleNoTenant
+	}
+
+	return []string{tenant}, nil
+}
+
+func (s *testNeedleTenantStrategy) Retention() needle.Retention {
+	return needle.RetainPerKey
+}
+
+func (s *testNeedleTenantStrategy) Create(
+	_ reflect.Type,
+	_ *needle.ResolutionOptions,
+	create func() (any, error),
+) (any, bool, error) {
+	inst, err := create()
+
+	return inst, false, err
+}
+
+func (s *testNeedleTenantStrategy) Dispose(ctx context.Context, _ reflect.Type, instance any) (bool, error) {
+	s.disposed = append(s.disposed, instance)
+
+	return needle.DisposeInstance(ctx, instance)
+}
+
+func testNeedleTenant(tenant string) needle.ResolutionOptionFunc {
+	return needle.WithContext(context.WithValue(context.Background(), testNeedleTenantKey{}, tenant))
+}
+
+func TestNeedle_CustomLifetime(t *testing.T) {
+	type Database struct{ id int }
+
+	strategy := &testNeedleTenantStrategy{}
+	registry := needle.NewRegistry(needle.WithLifetime(testNeedlePerTenant, strategy))
+
+	require.NoError(t, needle.RegisterToRegistry[Database](registry, testNeedlePerTenant))
+	require.ErrorIs(t, needle.RegisterToRegistry[Database](registry, testNeedlePerTenant), needle.ErrRegistered)
+
+	dbA, err := needle.ResolveFromRegistry[Database](registry, testNeedleTenant("a"))
+	require.NoError(t, err)
+
+	again, err := needle.ResolveFromRegistry[Database](registry, testNeedleTenant("a"))
+	require.NoError(t, err)
+	assert.Same(t, dbA, again)
+
+	dbB, err := needle.ResolveFromRegistry[Database](registry, testNeedleTenant("b"))
+	require.NoError(t, err)
+	assert.NotSame(t, dbA, dbB)
+
+	_, err = needle.ResolveFromRegistry[Database](registry)
+	require.ErrorIs(t, err, errTestNeedleNoTenant)
+
+	infos := registry.Describe()
+	require.Len(t, infos, 1)
+	assert.Equal(t, testNeedlePerTenant, infos[0].Lifetime)
+	require.Len(t, infos[0].Instances, 2)
+	assert.Equal(t, "a", infos[0].Instances[0].Key)
+	assert.Equal(t, "b", infos[0].Instances[1].Key)
+
+	require.NoError(t, needle.UnregisterFromRegistry[Database](registry))
+	assert.ElementsMatch(t, []any{dbA, dbB}, strategy.disposed)
+}
+
+func TestNeedle_CustomLifetimeInstance(t *testing.T) {
+	type Config struct{ tenant string }
+
+	registry := needle.NewRegistry(needle.WithLifetime(testNeedlePerTenant, &testNeedleTenantStrategy{}))
+	require.NoError(t, needle.RegisterInstanceToRegistry(registry, testNeedlePerTenant, &Config{tenant: "a"},
+		testNeedleTenant("a")))
+
+	config, err := needle.ResolveFromRegistry[Config](registry, testNeedleTenant("a"))
+	require.NoError(t, err)
+	assert.Equal(t, "a", config.tenant)
+
+	config, err = needle.ResolveFromRegistry[Config](registry, testNeedleTenant("b"))
+	require.NoError(t, err)
+	assert.Empty(t, config.tenant)
+}
+
+func TestNeedle_UnknownLifetime(t *testing.T) {
+	type Service struct{}
+
+	registry := needle.NewRegistry()
+
+	err := needle.RegisterToRegistry[Service](registry, testNeedlePerTenant)
+	require.ErrorIs(t, err, needle.ErrInvalidLifetime)
+	assert.Empty(t, registry.RegisteredServices())
+}
+
+const testNeedlePerJob needle.Lifetime = "PER_JOB"
+
+// testNeedleJobStrategy creates an instance per batch job, run within a scope, and disposes it once the scope is
+// closed.
+type testNeedleJobStrategy struct {
+	jobs     map[string]any
+	disposed []any
+	lock     sync.Mutex
+}
+
+func (s *testNeedleJobStrategy) Keys(*needle.ResolutionOptions) ([]string, error) {
+	return nil, nil
+}
+
+func (s *testNeedleJobStrategy) Retention() needle.Retention {
+	return needle.RetainNone
+}
+
+func (s *testNeedleJobStrategy) Create(
+	_ reflect.Type,
+	opt *needle.ResolutionOptions,
+	create func() (any, error),
+) (any, bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if inst, found := s.jobs[opt.Scope()]; found {
+		return inst, true, nil
+	}
+
+	inst, err := create()
+	if err == nil {
+		s.jobs[opt.Scope()] = inst
+	}
+
+	return inst, false, err
+}
+
+func (s *testNeedleJobStrategy) Dispose(ctx context.Context, _ reflect.Type, instance any) (bool, error) {
+	return needle.DisposeInstance(ctx, instance)
+}
+
+func (s *testNeedleJobStrategy) CloseScope(scope string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if inst, found := s.jobs[scope]; found {
+		s.disposed = append(s.disposed, inst)
+		delete(s.jobs, scope)
+	}
+}
+
+func TestNeedle_CustomLifetimeScopeCloser(t *testing.T) {
+	type Batch struct{ id int }
+
+	strategy := &testNeedleJobStrategy{jobs: make(map[string]any)}
+	registry := needle.NewRegistry(needle.WithLifetime(testNeedlePerJob, strategy))
+
+	require.NoError(t, needle.RegisterToRegistry[Batch](registry, testNeedlePerJob))
+	require.NoError(t, needle.OpenScopeInRegistry(registry, "job1"))
+
+	batch, err := needle.ResolveFromRegistry[Batch](registry, needle.WithScope("job1"))
+	require.NoError(t, err)
+
+	again, err := needle.ResolveFromRegistry[Batch](registry, needle.WithScope("job1"))
+	require.NoError(t, err)
+	assert.Same(t, batch, again)
+
+	require.NoError(t, needle.CloseScopeInRegistry(registry, "job1"))
+	assert.Equal(t, []any{batch}, strategy.disposed)
+}
+
+func TestNeedle_BuiltinLifetimeNotReplaced(t *testing.T) {
+	type Service struct{}
+
+	registry := needle.NewRegistry(needle.WithLifetime(needle.Scoped, &testNeedleTenantStrategy{}))
+
+	err := needle.RegisterToRegistry[Service](registry, needle.Singleton)
+	require.ErrorIs(t, err, needle.ErrBuiltinLifetime)
+	require.ErrorIs(t, registry.Validate(), needle.ErrBuiltinLifetime)
+
+	_, err = registry.Build(context.Background())
+	require.ErrorIs(t, err, needle.ErrBuiltinLifetime)
+}
+
+func TestNeedle_NilLifetimeStrategy(t *testing.T) {
+	type Service struct{}
+
+	registry := needle.NewRegistry(needle.WithLifetime(testNeedlePerTenant, nil))
+
+	err := needle.RegisterToRegistry[Service](registry, testNeedlePerTenant)
+	require.ErrorIs(t, err, needle.ErrNilStrategy)
+	require.ErrorIs(t, registry.Validate(), needle.ErrNilStrategy)
+}
+
+// testNeedleReentrantStrategy calls back into its registry from every method, as a strategy may.
+type testNeedleReentrantStrategy struct {
+	testNeedleTenantStrategy
+
+	registry *needle.Registry
+}
+
+func (s *testNeedleReentrantStrategy) Retention() needle.Retention {
+	if s.registry != nil {
+		_ = s.registry.RegisteredServices()
+	}
+
+	return needle.RetainPerKey
+}
+
+func TestNeedle_CustomLifetimeReentrant(t *testing.T) {
+	type Database struct{}
+
+	strategy := &testNeedleReentrantStrategy{} //nolint:exhaustruct
+	registry := needle.NewRegistry(needle.WithLifetime(testNeedlePerTenant, strategy))
+	strategy.registry = registry
+
+	require.NoError(t, needle.RegisterToRegistry[Database](registry, testNeedlePerTenant))
+
+	_, err := needle.ResolveFromRegistry[Database](registry, testNeedleTenant("a"))
+	require.NoError(t, err)
+	require.NoError(t, needle.UnregisterFromRegistry[Database](registry))
+}
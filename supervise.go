@@ -0,0 +1,333 @@
+package needle
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Runner is implemented by singleton services running in the background until their context is canceled,
+// such as queue consumers and tickers. Runners are started and supervised by Supervise.
+type Runner interface {
+	Run(ctx context.Context) error
+}
+
+// RestartPolicy defines when a supervised runner is restarted after Run returns.
+type RestartPolicy string
+
+const (
+	// RestartNever never restarts a runner.
+	RestartNever RestartPolicy = "never"
+	// RestartOnFailure restarts a runner, with backoff, when Run returns an error or panics.
+	RestartOnFailure RestartPolicy = "on-failure"
+	// RestartAlways restarts a runner, with backoff, whenever Run returns.
+	RestartAlways RestartPolicy = "always"
+)
+
+// RunnerState represents the state of a supervised runner.
+type RunnerState string
+
+const (
+	// RunnerRunning indicates that the runner is running.
+	RunnerRunning RunnerState = "running"
+	// RunnerRestarting indicates that the runner returned and waits for its backoff before restarting.
+	RunnerRestarting RunnerState = "restarting"
+	// RunnerCompleted indicates that the runner returned without error and is not restarted.
+	RunnerCompleted RunnerState = "completed"
+	// RunnerFailed indicates that the runner failed and is not restarted.
+	RunnerFailed RunnerState = "failed"
+	// RunnerStopped indicates that the runner returned after its supervisor was stopped.
+	RunnerStopped RunnerState = "stopped"
+)
+
+const (
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// SuperviseOptions holds configuration options for supervising runners.
+type SuperviseOptions struct {
+	policy      RestartPolicy
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	maxRestarts int
+}
+
+// SuperviseOptionFunc is a function that modifies a SuperviseOptions struct.
+type SuperviseOptionFunc func(*SuperviseOptions)
+
+// WithRestartPolicy sets when runners are restarted after Run returns. Defaults to RestartOnFailure.
+//
+// Example:
+//
+//	supervisor, err := registry.Supervise(ctx, needle.WithRestartPolicy(needle.RestartAlways))
+func WithRestartPolicy(policy RestartPolicy) SuperviseOptionFunc {
+	return func(o *SuperviseOptions) {
+		o.policy = policy
+	}
+}
+
+// WithBackoff sets the delay before restarting a runner. The delay starts at minBackoff, doubles after each
+// consecutive failure up to maxBackoff, and is reset once Run returns without error. Defaults to 100ms and 30s.
+//
+// Example:
+//
+//	supervisor, err := registry.Supervise(ctx, needle.WithBackoff(time.Second, time.Minute))
+func WithBackoff(minBackoff, maxBackoff time.Duration) SuperviseOptionFunc {
+	return func(o *SuperviseOptions) {
+		o.minBackoff = minBackoff
+		o.maxBackoff = max(minBackoff, maxBackoff)
+	}
+}
+
+// WithMaxRestarts sets the maximum number of times a runner is restarted. Zero, the default, means no limit.
+//
+// Example:
+//
+//	supervisor, err := registry.Supervise(ctx, needle.WithMaxRestarts(5))
+func WithMaxRestarts(n int) SuperviseOptionFunc {
+	return func(o *SuperviseOptions) {
+		o.maxRestarts = max(n, 0)
+	}
+}
+
+// newSuperviseOptions creates a new SuperviseOptions struct from the provided option functions.
+func newSuperviseOptions(optFuncs ...SuperviseOptionFunc) *SuperviseOptions {
+	opt := &SuperviseOptions{
+		policy:      RestartOnFailure,
+		minBackoff:  defaultMinBackoff,
+		maxBackoff:  defaultMaxBackoff,
+		maxRestarts: 0,
+	}
+
+	for _, optFunc := range optFuncs {
+		optFunc(opt)
+	}
+
+	return opt
+}
+
+// RunnerStatus describes the state of a supervised runner.
+type RunnerStatus struct {
+	Name      string       // Display name of the service.
+	Type      reflect.Type // Type of the service.
+	State     RunnerState  // Current state of the runner.
+	Restarts  int          // Number of times the runner was restarted.
+	StartedAt time.Time    // Time the runner was last started.
+	LastError error        // Error returned by the last failed run, nil if the runner never failed.
+}
+
+// Supervisor runs and restarts the runners registered in a registry.
+type Supervisor struct {
+	cancel   context.CancelFunc
+	done     chan struct{}
+	statuses []*RunnerStatus
+	lock     sync.RWMutex
+}
+
+//nolint:gochecknoglobals
+var runnerType = reflect.TypeFor[Runner]()
+
+// Supervise starts every singleton service of the global registry implementing Runner.
+// See Registry.Supervise for details.
+func Supervise(ctx context.Context, optFuncs ...SuperviseOptionFunc) (*Supervisor, error) {
+	ensureGlobalRegistryInitialized()
+
+	return globalRegistry.Supervise(ctx, optFuncs...)
+}
+
+// Supervise resolves every singleton service implementing Runner and runs each of them in its own goroutine
+// until the context is canceled or the supervisor is stopped. Runners returning, failing or panicking are
+// restarted according to the restart policy.
+//
+// Returns an error, and starts no runner, if a runner cannot be resolved.
+//
+// Example:
+//
+//	supervisor, err := registry.Supervise(ctx, needle.WithRestartPolicy(needle.RestartOnFailure))
+//	if err != nil {
+//	    ...
+//	}
+//	defer supervisor.Stop(context.Background())
+func (r *Registry) Supervise(ctx context.Context, optFuncs ...SuperviseOptionFunc) (*Supervisor, error) {
+	opt := newSuperviseOptions(optFuncs...)
+	entries := r.snapshotEntries()
+
+	var (
+		runners  []Runner
+		statuses []*RunnerStatus
+	)
+
+	for _, typ := range sortedTypes(entries) {
+		if entries[typ].lifetime != Singleton || !reflect.PointerTo(typ).Implements(runnerType) {
+			continue
+		}
+
+		inst, err := resolveType(r, typ, newResolutionOptions())
+		if err != nil {
+			return nil, err
+		}
+
+		runner, _ := inst.(Runner)
+		runners = append(runners, runner)
+		statuses = append(statuses, &RunnerStatus{
+			Name:      internal.ServiceName(typ),
+			Type:      typ,
+			State:     RunnerRunning,
+			Restarts:  0,
+			StartedAt: time.Time{},
+			LastError: nil,
+		})
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	supervisor := &Supervisor{cancel: cancel, done: make(chan struct{}), statuses: statuses} //nolint:exhaustruct
+
+	var waitGroup sync.WaitGroup
+
+	for idx, runner := range runners {
+		waitGroup.Add(1)
+
+		go func() {
+			defer waitGroup.Done()
+
+			supervisor.supervise(runCtx, runner, statuses[idx], opt)
+		}()
+	}
+
+	go func() {
+		waitGroup.Wait()
+		close(supervisor.done)
+	}()
+
+	return supervisor, nil
+}
+
+// supervise runs a runner until it is not restarted anymore.
+func (s *Supervisor) supervise(ctx context.Context, runner Runner, status *RunnerStatus, opt *SuperviseOptions) {
+	backoff := opt.minBackoff
+
+	for {
+		s.update(status, func(st *RunnerStatus) {
+			st.State = RunnerRunning
+			st.StartedAt = time.Now()
+		})
+
+		err := run(ctx, runner)
+
+		if ctx.Err() != nil {
+			s.update(status, func(st *RunnerStatus) { st.State = RunnerStopped })
+
+			return
+		}
+
+		if err != nil {
+			s.update(status, func(st *RunnerStatus) { st.LastError = err })
+		} else {
+			backoff = opt.minBackoff
+		}
+
+		restart := opt.policy == RestartAlways || (opt.policy == RestartOnFailure && err != nil)
+		if restart && opt.maxRestarts > 0 && s.restarts(status) >= opt.maxRestarts {
+			restart = false
+		}
+
+		if !restart {
+			s.update(status, func(st *RunnerStatus) {
+				st.State = RunnerCompleted
+				if err != nil {
+					st.State = RunnerFailed
+				}
+			})
+
+			return
+		}
+
+		s.update(status, func(st *RunnerStatus) { st.State = RunnerRestarting })
+
+		timer := time.NewTimer(backoff)
+
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			s.update(status, func(st *RunnerStatus) { st.State = RunnerStopped })
+
+			return
+		}
+
+		if err != nil {
+			backoff = min(backoff*2, opt.maxBackoff) //nolint:mnd
+		}
+
+		s.update(status, func(st *RunnerStatus) { st.Restarts++ })
+	}
+}
+
+// run calls Run on a runner, turning a panic into an error.
+func run(ctx context.Context, runner Runner) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = fmt.Errorf("%w: %v", ErrRunnerPanic, recovered)
+		}
+	}()
+
+	return runner.Run(ctx)
+}
+
+// update modifies the status of a runner while holding the supervisor lock.
+func (s *Supervisor) update(status *RunnerStatus, modify func(*RunnerStatus)) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	modify(status)
+}
+
+// restarts returns the number of times a runner was restarted.
+func (s *Supervisor) restarts(status *RunnerStatus) int {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return status.Restarts
+}
+
+// Status returns the status of every supervised runner, sorted by name.
+func (s *Supervisor) Status() []RunnerStatus {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	statuses := make([]RunnerStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		statuses = append(statuses, *status)
+	}
+
+	slices.SortFunc(statuses, func(a, b RunnerStatus) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return statuses
+}
+
+// Done returns a channel closed once every runner has returned and will not be restarted.
+func (s *Supervisor) Done() <-chan struct{} {
+	return s.done
+}
+
+// Stop cancels the context of every runner and waits until they return or the context is done.
+// Returns the context error if the runners did not return in time.
+func (s *Supervisor) Stop(ctx context.Context) error {
+	s.cancel()
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("%w: %w", ErrRunnerStop, ctx.Err())
+	}
+}
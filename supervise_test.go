@@ -0,0 +1,163 @@
+package needle_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNeedleRunner = errors.New("runner failed")
+
+type testNeedleRunner struct {
+	runs  atomic.Int32
+	fails int32
+	panic bool
+}
+
+func (r *testNeedleRunner) Run(ctx context.Context) error {
+	if r.runs.Add(1) <= r.fails {
+		if r.panic {
+			panic("boom")
+		}
+
+		return errTestNeedleRunner
+	}
+
+	<-ctx.Done()
+
+	return nil
+}
+
+type testNeedleTicker struct{ runs atomic.Int32 }
+
+func (t *testNeedleTicker) Run(context.Context) error {
+	t.runs.Add(1)
+
+	return nil
+}
+
+func TestNeedle_Supervise(t *testing.T) {
+	registry := needle.NewRegistry()
+	runner := &testNeedleRunner{fails: 2} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, runner))
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, &testNeedleShutdownLog{})) //nolint:exhaustruct
+
+	supervisor, err := registry.Supervise(context.Background(), needle.WithBackoff(time.Millisecond, time.Millisecond))
+	require.NoError(t, err)
+	require.Len(t, supervisor.Status(), 1)
+
+	require.Eventually(t, func() bool {
+		return runner.runs.Load() == 3 && supervisor.Status()[0].State == needle.RunnerRunning
+	}, time.Second, time.Millisecond)
+
+	require.NoError(t, supervisor.Stop(context.Background()))
+
+	status := supervisor.Status()[0]
+	assert.Equal(t, "github.com/goplexhq/needle_test.testNeedleRunner", status.Name)
+	assert.Equal(t, needle.RunnerStopped, status.State)
+	assert.Equal(t, 2, status.Restarts)
+	require.ErrorIs(t, status.LastError, errTestNeedleRunner)
+}
+
+func TestNeedle_Supervise_Panic(t *testing.T) {
+	registry := needle.NewRegistry()
+	runner := &testNeedleRunner{fails: 1, panic: true} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, runner))
+
+	supervisor, err := registry.Supervise(context.Background(), needle.WithRestartPolicy(needle.RestartNever))
+	require.NoError(t, err)
+
+	<-supervisor.Done()
+
+	status := supervisor.Status()[0]
+	assert.Equal(t, needle.RunnerFailed, status.State)
+	assert.Equal(t, 0, status.Restarts)
+	require.ErrorIs(t, status.LastError, needle.ErrRunnerPanic)
+}
+
+func TestNeedle_Supervise_MaxRestarts(t *testing.T) {
+	registry := needle.NewRegistry()
+	runner := &testNeedleRunner{fails: 10} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, runner))
+
+	supervisor, err := registry.Supervise(
+		context.Background(),
+		needle.WithBackoff(time.Millisecond, time.Millisecond),
+		needle.WithMaxRestarts(3),
+	)
+	require.NoError(t, err)
+
+	<-supervisor.Done()
+
+	status := supervisor.Status()[0]
+	assert.Equal(t, needle.RunnerFailed, status.State)
+	assert.Equal(t, 3, status.Restarts)
+	assert.Equal(t, int32(4), runner.runs.Load())
+}
+
+func TestNeedle_Supervise_RestartPolicy(t *testing.T) {
+	registry := needle.NewRegistry()
+	ticker := &testNeedleTicker{} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(registry, ticker))
+
+	supervisor, err := registry.Supervise(context.Background())
+	require.NoError(t, err)
+
+	<-supervisor.Done()
+	assert.Equal(t, needle.RunnerCompleted, supervisor.Status()[0].State)
+	assert.Equal(t, int32(1), ticker.runs.Load())
+
+	supervisor, err = registry.Supervise(
+		context.Background(),
+		needle.WithRestartPolicy(needle.RestartAlways),
+		needle.WithBackoff(time.Millisecond, time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return ticker.runs.Load() > 3 }, time.Second, time.Millisecond)
+	require.NoError(t, supervisor.Stop(context.Background()))
+	assert.Equal(t, needle.RunnerStopped, supervisor.Status()[0].State)
+}
+
+func TestNeedle_Supervise_ResolveFailure(t *testing.T) {
+	registry := needle.NewRegistry()
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleTicker](registry, needle.Singleton,
+		func() (*testNeedleTicker, error) { return nil, errTestNeedleRunner }))
+
+	_, err := registry.Supervise(context.Background())
+	require.ErrorIs(t, err, errTestNeedleRunner)
+}
+
+func TestNeedle_App_Runners(t *testing.T) {
+	app := needle.NewApp()
+	runner := &testNeedleRunner{} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstanceToRegistry(app.Registry(), runner))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan int)
+
+	go func() { done <- app.Run(ctx) }()
+
+	require.Eventually(t, func() bool {
+		runners := app.Runners()
+
+		return len(runners) == 1 && runners[0].State == needle.RunnerRunning
+	}, time.Second, time.Millisecond)
+
+	cancel()
+
+	assert.Equal(t, needle.ExitOK, <-done)
+	assert.Nil(t, app.Runners())
+}
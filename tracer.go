@@ -0,0 +1,83 @@
+package needle
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Span names started by the registry, followed by the name of the service.
+const (
+	SpanResolve = "needle.resolve" // Resolution of a service, including the resolution of its dependencies.
+	SpanFactory = "needle.factory" // Call to the factory of a service.
+)
+
+// Attribute keys set on the spans started by the registry.
+const (
+	AttributeService  = "needle.service"
+	AttributeLifetime = "needle.lifetime"
+	AttributeScope    = "needle.scope"
+	AttributeThread   = "needle.thread"
+	AttributeCacheHit = "needle.cache_hit"
+)
+
+// Tracer starts the spans the registry records around resolutions and factory calls. Like OpenTelemetry, the
+// started span is carried by the returned context, in which the spans of dependencies are started, so that they
+// are nested along the dependency chain. Tracers must be safe for concurrent use.
+type Tracer interface {
+	Start(ctx context.Context, name string, attrs ...Attribute) (context.Context, Span)
+}
+
+// Span is a span started by a Tracer.
+type Span interface {
+	SetAttributes(attrs ...Attribute)
+	End(err error)
+}
+
+// Attribute is a key-value pair describing a span.
+type Attribute struct {
+	Key   string
+	Value string
+}
+
+// nopSpan is a Span doing nothing, used when the registry has no tracer.
+type nopSpan struct{}
+
+func (nopSpan) SetAttributes(...Attribute) {}
+
+func (nopSpan) End(error) {}
+
+// startSpan starts a span for an operation on a service and returns a copy of the options carrying the span.
+func (r *Registry) startSpan(opt *ResolutionOptions, name string, typ reflect.Type, lifetime Lifetime) (
+	*ResolutionOptions,
+	Span,
+) {
+	if r.tracer == nil {
+		return opt, nopSpan{}
+	}
+
+	serviceName := internal.ServiceName(typ)
+	attrs := []Attribute{
+		{Key: AttributeService, Value: serviceName},
+		{Key: AttributeLifetime, Value: lifetime.String()},
+	}
+
+	if opt.scope != "" {
+		attrs = append(attrs, Attribute{Key: AttributeScope, Value: opt.scope})
+	}
+
+	if lifetime == ThreadLocal {
+		attrs = append(attrs, Attribute{Key: AttributeThread, Value: opt.threadID})
+	}
+
+	ctx, span := r.tracer.Start(opt.Context(), name+" "+serviceName, attrs...)
+
+	return opt.withContext(ctx), span
+}
+
+// cacheHitAttribute returns the attribute reporting whether a resolution returned an instance held by the registry.
+func cacheHitAttribute(cacheHit bool) Attribute {
+	return Attribute{Key: AttributeCacheHit, Value: strconv.FormatBool(cacheHit)}
+}
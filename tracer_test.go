@@ -0,0 +1,65 @@
+package needle_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/goplexhq/needle/needletrace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNeedle_Tracer(t *testing.T) {
+	recorder := needletrace.NewRecorder()
+	registry := needle.NewRegistry(needle.WithTracer(recorder))
+
+	require.NoError(t, needle.RegisterFactoryToRegistry[testNeedleShutdownDatabase](registry, needle.Singleton,
+		func() *testNeedleShutdownDatabase { return &testNeedleShutdownDatabase{} })) //nolint:exhaustruct
+	require.NoError(t, needle.RegisterToRegistry[testNeedleShutdownRepo](registry, needle.Scoped,
+		needle.WithScope("request1")))
+
+	ctx, parent := recorder.Start(context.Background(), "request")
+
+	_, err := needle.ResolveFromRegistry[testNeedleShutdownRepo](registry,
+		needle.WithScope("request1"), needle.WithContext(ctx))
+	require.NoError(t, err)
+
+	parent.End(nil)
+
+	spans := recorder.Spans()
+	require.Len(t, spans, 4)
+
+	request, repo, database, factory := spans[0], spans[1], spans[2], spans[3]
+
+	assert.Equal(t, needle.SpanResolve+" github.com/goplexhq/needle_test.testNeedleShutdownRepo", repo.Name)
+	assert.Equal(t, request.ID, repo.ParentID)
+	assert.Equal(t, "SCOPED", repo.Attributes[needle.AttributeLifetime])
+	assert.Equal(t, "request1", repo.Attributes[needle.AttributeScope])
+	assert.Equal(t, "false", repo.Attributes[needle.AttributeCacheHit])
+
+	assert.Equal(t, needle.SpanResolve+" github.com/goplexhq/needle_test.testNeedleShutdownDatabase", database.Name)
+	assert.Equal(t, repo.ID, database.ParentID)
+
+	assert.Equal(t, needle.SpanFactory+" github.com/goplexhq/needle_test.testNeedleShutdownDatabase", factory.Name)
+	assert.Equal(t, database.ID, factory.ParentID)
+
+	for _, span := range spans {
+		assert.True(t, span.Ended(), span.Name)
+		require.NoError(t, span.Err)
+	}
+
+	recorder.Reset()
+
+	_, err = needle.ResolveFromRegistry[testNeedleShutdownDatabase](registry)
+	require.NoError(t, err)
+
+	_, err = needle.ResolveFromRegistry[testNeedleShutdownSession](registry)
+	require.Error(t, err)
+
+	spans = recorder.Spans()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "true", spans[0].Attributes[needle.AttributeCacheHit])
+	assert.Zero(t, spans[0].ParentID)
+	require.ErrorIs(t, spans[1].Err, needle.ErrNotRegistered)
+}
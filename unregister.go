@@ -0,0 +1,140 @@
+package needle
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/goplexhq/needle/internal"
+)
+
+// Unregister removes a service from the global registry, closing removed instances that implement io.Closer.
+// Returns an error if the service is not registered or an instance fails to close.
+//
+// The optFuncs parameter allows for optional configuration of the removal, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Removes only the instance of a scoped service held by the scope.
+//   - WithThreadID(threadID string): Removes only the instance of a thread-local service held by the thread.
+//
+// Without options, the registration and all of its instances are removed. A registration is also removed once
+// its last scoped or thread-local instance is removed.
+//
+// Example:
+//
+//	err := needle.Unregister[MyService]()
+//	if err != nil {
+//	    ...
+//	}
+//
+// Example with scope:
+//
+//	err := needle.Unregister[MyService](needle.WithScope("request1"))
+//	if err != nil {
+//	    ...
+//	}
+func Unregister[T any](optFuncs ...ResolutionOptionFunc) error {
+	ensureGlobalRegistryInitialized()
+
+	return UnregisterFromRegistry[T](globalRegistry, optFuncs...)
+}
+
+// UnregisterFromRegistry removes a service from the registry, closing removed instances that implement io.Closer.
+// Returns an error if the service is not registered or an instance fails to close.
+//
+// The optFuncs parameter allows for optional configuration of the removal, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Removes only the instance of a scoped service held by the scope.
+//   - WithThreadID(threadID string): Removes only the instance of a thread-local service held by the thread.
+//
+// Without options, the registration and all of its instances are removed. A registration is also removed once
+// its last scoped or thread-local instance is removed.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	err := needle.UnregisterFromRegistry[MyService](registry, needle.WithThreadID("thread1"))
+//	if err != nil {
+//	    ...
+//	}
+func UnregisterFromRegistry[T any](registry *Registry, optFuncs ...ResolutionOptionFunc) error {
+	typ := reflect.TypeFor[T]()
+
+	removed, found := registry.remove(typ, newResolutionOptions(optFuncs...))
+	if !found {
+		return fmt.Errorf("%w: %s", ErrNotRegistered, internal.ServiceName(typ))
+	}
+
+	err := registry.dispose(removed.instances...)
+
+	registry.release(removed)
+
+	return err
+}
+
+// Replace atomically swaps the instance of a service registered in the global registry, keeping its lifetime,
+// and closes the previous instance if it implements io.Closer. The template of a prototype is swapped without
+// closing the previous template. A singleton registered with WithTTL or WithRefresh keeps the new instance, which
+// neither expires nor is refreshed.
+// Returns an error if the service is not registered, is Transient, or the previous instance fails to close.
+//
+// The optFuncs parameter allows for optional configuration of the replacement, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets the scope holding the instance. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets the thread ID holding the instance. Optional and defaults
+//     to the current goroutine ID if not provided and the lifetime is ThreadLocal.
+//
+// Example:
+//
+//	err := needle.Replace(&MyService{Enabled: true})
+//	if err != nil {
+//	    ...
+//	}
+func Replace[T any](val *T, optFuncs ...ResolutionOptionFunc) error {
+	ensureGlobalRegistryInitialized()
+
+	return ReplaceInRegistry[T](globalRegistry, val, optFuncs...)
+}
+
+// ReplaceInRegistry atomically swaps the instance of a service registered in the registry, keeping its lifetime,
+// and closes the previous instance if it implements io.Closer. The template of a prototype is swapped without
+// closing the previous template. A singleton registered with WithTTL or WithRefresh keeps the new instance, which
+// neither expires nor is refreshed.
+// Returns an error if the service is not registered, is Transient, or the previous instance fails to close.
+//
+// The optFuncs parameter allows for optional configuration of the replacement, such as setting a scope or thread ID.
+//
+// Available options:
+//   - WithScope(scope string): Sets the scope holding the instance. Required when lifetime is Scoped.
+//   - WithThreadID(threadID string): Sets the thread ID holding the instance. Optional and defaults
+//     to the current goroutine ID if not provided and the lifetime is ThreadLocal.
+//
+// Example:
+//
+//	registry := needle.NewRegistry()
+//	err := needle.ReplaceInRegistry(registry, &MyService{Enabled: true}, needle.WithScope("request1"))
+//	if err != nil {
+//	    ...
+//	}
+func ReplaceInRegistry[T any](registry *Registry, val *T, optFuncs ...ResolutionOptionFunc) error {
+	opt := newResolutionOptions(optFuncs...)
+
+	if opt.threadID == "" {
+		opt.threadID = internal.GetGoroutineID()
+	}
+
+	typ := reflect.TypeFor[T]()
+
+	key, err := registry.instanceKey(typ, opt)
+	if err != nil {
+		return err
+	}
+
+	old, err := registry.replace(typ, reflect.ValueOf(val), key)
+	if err != nil {
+		return err
+	}
+
+	return registry.dispose(old)
+}
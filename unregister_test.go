@@ -0,0 +1,128 @@
+package needle_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/goplexhq/needle"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestNeedleCloser = errors.New("close failed")
+
+type testNeedleCloser struct {
+	name   string
+	closed bool
+	fail   bool
+}
+
+func (c *testNeedleCloser) Close() error {
+	c.closed = true
+
+	if c.fail {
+		return errTestNeedleCloser
+	}
+
+	return nil
+}
+
+func TestNeedle_Unregister(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	instance := &testNeedleCloser{name: "singleton"} //nolint:exhaustruct
+
+	require.NoError(t, needle.RegisterSingletonInstance(instance))
+	require.NoError(t, needle.Unregister[testNeedleCloser]())
+	assert.True(t, instance.closed)
+	assert.Empty(t, needle.RegisteredServices())
+
+	_, err := needle.Resolve[testNeedleCloser]()
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+	require.ErrorIs(t, needle.Unregister[testNeedleCloser](), needle.ErrNotRegistered)
+}
+
+func TestNeedle_UnregisterScope(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	instanceA := &testNeedleCloser{name: "a"} //nolint:exhaustruct
+	instanceB := &testNeedleCloser{name: "b"} //nolint:exhaustruct
+
+	optA, optB := needle.WithScope("a"), needle.WithScope("b")
+
+	require.NoError(t, needle.RegisterScopedInstance(instanceA, optA))
+	require.NoError(t, needle.RegisterScopedInstance(instanceB, optB))
+
+	require.NoError(t, needle.Unregister[testNeedleCloser](optA))
+	assert.True(t, instanceA.closed)
+	assert.False(t, instanceB.closed)
+
+	_, err := needle.Resolve[testNeedleCloser](optA)
+	require.ErrorIs(t, err, needle.ErrNotRegistered)
+
+	val, err := needle.Resolve[testNeedleCloser](optB)
+	require.NoError(t, err)
+	assert.Same(t, instanceB, val)
+
+	require.NoError(t, needle.Unregister[testNeedleCloser](optB))
+	assert.Empty(t, needle.RegisteredServices())
+}
+
+func TestNeedle_UnregisterFromRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	registry := needle.NewRegistry()
+
+	instance := &testNeedleCloser{name: "thread", fail: true} //nolint:exhaustruct
+	opt := needle.WithThreadID("thread1")
+
+	require.NoError(t, needle.RegisterThreadLocalInstanceToRegistry(registry, instance, opt))
+
+	err := needle.UnregisterFromRegistry[testNeedleCloser](registry, opt)
+	require.ErrorIs(t, err, needle.ErrDispose)
+	require.ErrorIs(t, err, errTestNeedleCloser)
+	assert.True(t, instance.closed)
+	assert.Empty(t, registry.RegisteredServices())
+}
+
+func TestNeedle_Replace(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	oldInstance := &testNeedleCloser{name: "old"} //nolint:exhaustruct
+	newInstance := &testNeedleCloser{name: "new"} //nolint:exhaustruct
+
+	require.ErrorIs(t, needle.Replace(newInstance), needle.ErrNotRegistered)
+
+	require.NoError(t, needle.RegisterSingletonInstance(oldInstance))
+	require.NoError(t, needle.Replace(newInstance))
+	assert.True(t, oldInstance.closed)
+	assert.False(t, newInstance.closed)
+
+	val, err := needle.Resolve[testNeedleCloser]()
+	require.NoError(t, err)
+	assert.Same(t, newInstance, val)
+}
+
+func TestNeedle_ReplaceInRegistry(t *testing.T) {
+	t.Cleanup(needle.Reset)
+
+	type testStruct struct{ name string }
+
+	registry := needle.NewRegistry()
+	opt := needle.WithScope("scope")
+
+	require.NoError(t, needle.RegisterToRegistry[testStruct](registry, needle.Scoped, opt))
+
+	replacement := &testStruct{name: "replacement"}
+
+	require.ErrorIs(t, needle.ReplaceInRegistry(registry, replacement), needle.ErrEmptyScope)
+	require.ErrorIs(t, needle.ReplaceInRegistry(registry, replacement, needle.WithScope("other")), needle.ErrNotRegistered)
+	require.NoError(t, needle.ReplaceInRegistry(registry, replacement, opt))
+
+	val, err := needle.ResolveFromRegistry[testStruct](registry, opt)
+	require.NoError(t, err)
+	assert.Same(t, replacement, val)
+
+	require.NoError(t, needle.RegisterToRegistry[int](registry, needle.Transient))
+	require.ErrorIs(t, needle.ReplaceInRegistry(registry, new(int)), needle.ErrTransientInstance)
+}